@@ -0,0 +1,289 @@
+// Package config resolves the simulator settings at startup.
+//
+// Settings are layered, each source overriding the one before it:
+//
+//  1. built-in defaults
+//  2. an optional YAML file (-config flag or CONFIG_FILE)
+//  3. environment variables
+//  4. command-line flags
+//
+// Data paths are per sport. For a sport named "cricket" the environment
+// variables are PREMATCH_CRICKET_PATH and CRICKET_RESULT_PATH and the flags
+// are -cricket-prematch and -cricket-result.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the resolved settings.
+type Config struct {
+	Port        string   `yaml:"port"`
+	CORSOrigins []string `yaml:"cors_origins"`
+	LogLevel    string   `yaml:"log_level"`
+	// ReloadInterval is how often data files are checked for changes. Zero
+	// disables reloading. readFile decodes the file's reload_interval itself.
+	ReloadInterval time.Duration `yaml:"-"`
+	// DoubleChanceMargin is the margin applied when Double Chance prices are
+	// derived from 1X2 prices, as a decimal fraction ("0.05" is 5%).
+	DoubleChanceMargin string `yaml:"double_chance_margin"`
+	// CricketTieRule settles To Win the Match when the runs are level: "void",
+	// "dead_heat" or "super_over".
+	CricketTieRule string               `yaml:"cricket_tie_rule"`
+	Data           map[string]DataPaths `yaml:"data"`
+}
+
+// CricketTieRules lists the valid values of CricketTieRule.
+var CricketTieRules = []string{"void", "dead_heat", "super_over"}
+
+// DataPaths locates the prematch and result files of one sport.
+type DataPaths struct {
+	Prematch string `yaml:"prematch"`
+	Result   string `yaml:"result"`
+}
+
+// Default returns the settings used when nothing else is configured.
+func Default(sportNames []string) Config {
+	cfg := Config{
+		Port:               "3000",
+		CORSOrigins:        []string{"*"},
+		LogLevel:           "info",
+		ReloadInterval:     2 * time.Second,
+		DoubleChanceMargin: "0.05",
+		CricketTieRule:     "super_over",
+		Data:               make(map[string]DataPaths, len(sportNames)),
+	}
+	for _, name := range sportNames {
+		cfg.Data[name] = DataPaths{
+			Prematch: fmt.Sprintf("data/%s_prematch.json", name),
+			Result:   fmt.Sprintf("data/%s_result.json", name),
+		}
+	}
+	return cfg
+}
+
+// Load resolves the settings for the given sports from all sources and
+// validates them. args are the command-line arguments without the program
+// name.
+func Load(args []string, sportNames []string) (Config, error) {
+	cfg := Default(sportNames)
+
+	fs := flag.NewFlagSet("bet365-fiber-sim", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	port := fs.String("port", "", "port to listen on")
+	corsOrigins := fs.String("cors-origins", "", "comma-separated list of allowed CORS origins")
+	logLevel := fs.String("log-level", "", "log level (debug, info, warn, error)")
+	reloadInterval := fs.String("reload-interval", "", "how often to check data files for changes, 0 to disable")
+	doubleChanceMargin := fs.String("double-chance-margin", "", "margin for derived Double Chance prices, e.g. 0.05")
+	cricketTieRule := fs.String("cricket-tie-rule", "", "how a tied cricket match settles (void, dead_heat, super_over)")
+	prematch := make(map[string]*string, len(sportNames))
+	result := make(map[string]*string, len(sportNames))
+	for _, name := range sportNames {
+		prematch[name] = fs.String(name+"-prematch", "", "path to the "+name+" prematch file")
+		result[name] = fs.String(name+"-result", "", "path to the "+name+" result file")
+	}
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	if *configFile != "" {
+		if err := cfg.readFile(*configFile); err != nil {
+			return cfg, err
+		}
+	}
+
+	if err := cfg.readEnv(sportNames); err != nil {
+		return cfg, err
+	}
+
+	setString(&cfg.Port, *port)
+	setString(&cfg.LogLevel, *logLevel)
+	setString(&cfg.DoubleChanceMargin, *doubleChanceMargin)
+	setString(&cfg.CricketTieRule, *cricketTieRule)
+	if *corsOrigins != "" {
+		cfg.CORSOrigins = splitList(*corsOrigins)
+	}
+	if err := setDuration(&cfg.ReloadInterval, *reloadInterval); err != nil {
+		return cfg, fmt.Errorf("-reload-interval: %v", err)
+	}
+	for _, name := range sportNames {
+		paths := cfg.Data[name]
+		setString(&paths.Prematch, *prematch[name])
+		setString(&paths.Result, *result[name])
+		cfg.Data[name] = paths
+	}
+
+	return cfg, cfg.Validate(sportNames)
+}
+
+func (cfg *Config) readFile(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	// reload_interval is read as text so that a bare 0 parses, and through a
+	// pointer so that it is applied whenever the key is present.
+	var file struct {
+		Config         `yaml:",inline"`
+		ReloadInterval *string `yaml:"reload_interval"`
+	}
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+
+	setString(&cfg.Port, file.Port)
+	setString(&cfg.LogLevel, file.LogLevel)
+	setString(&cfg.DoubleChanceMargin, file.DoubleChanceMargin)
+	setString(&cfg.CricketTieRule, file.CricketTieRule)
+	if len(file.CORSOrigins) > 0 {
+		cfg.CORSOrigins = file.CORSOrigins
+	}
+	if file.ReloadInterval != nil {
+		if err := setDuration(&cfg.ReloadInterval, *file.ReloadInterval); err != nil {
+			return fmt.Errorf("config file %s: reload_interval: %v", path, err)
+		}
+	}
+	for name, filePaths := range file.Data {
+		paths := cfg.Data[name]
+		setString(&paths.Prematch, filePaths.Prematch)
+		setString(&paths.Result, filePaths.Result)
+		cfg.Data[name] = paths
+	}
+	return nil
+}
+
+func (cfg *Config) readEnv(sportNames []string) error {
+	setString(&cfg.Port, os.Getenv("INTERNAL_PORT"))
+	setString(&cfg.LogLevel, os.Getenv("LOG_LEVEL"))
+	setString(&cfg.DoubleChanceMargin, os.Getenv("DOUBLE_CHANCE_MARGIN"))
+	setString(&cfg.CricketTieRule, os.Getenv("CRICKET_TIE_RULE"))
+	if origins := os.Getenv("CORS_ORIGINS"); origins != "" {
+		cfg.CORSOrigins = splitList(origins)
+	}
+	for _, name := range sportNames {
+		upper := strings.ToUpper(name)
+		paths := cfg.Data[name]
+		setString(&paths.Prematch, os.Getenv("PREMATCH_"+upper+"_PATH"))
+		setString(&paths.Result, os.Getenv(upper+"_RESULT_PATH"))
+		cfg.Data[name] = paths
+	}
+	if err := setDuration(&cfg.ReloadInterval, os.Getenv("RELOAD_INTERVAL")); err != nil {
+		return fmt.Errorf("RELOAD_INTERVAL: %v", err)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once.
+func (cfg Config) Validate(sportNames []string) error {
+	var errs []error
+
+	if p, err := strconv.Atoi(cfg.Port); err != nil || p < 1 || p > 65535 {
+		errs = append(errs, fmt.Errorf("port %q is not a valid TCP port", cfg.Port))
+	}
+	if len(cfg.CORSOrigins) == 0 {
+		errs = append(errs, errors.New("cors_origins must not be empty"))
+	}
+	if cfg.ReloadInterval < 0 {
+		errs = append(errs, fmt.Errorf("reload_interval %s must not be negative", cfg.ReloadInterval))
+	}
+	if _, err := parseLevel(cfg.LogLevel); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := parseMargin(cfg.DoubleChanceMargin); err != nil {
+		errs = append(errs, err)
+	}
+	if !slices.Contains(CricketTieRules, cfg.CricketTieRule) {
+		errs = append(errs, fmt.Errorf("cricket_tie_rule %q is not one of %s", cfg.CricketTieRule, strings.Join(CricketTieRules, ", ")))
+	}
+	for _, name := range sportNames {
+		paths := cfg.Data[name]
+		errs = append(errs, checkFile(name+" prematch", paths.Prematch))
+		errs = append(errs, checkFile(name+" result", paths.Result))
+	}
+
+	return errors.Join(errs...)
+}
+
+// SlogLevel returns the configured log level. It assumes the config has been
+// validated.
+func (cfg Config) SlogLevel() slog.Level {
+	level, _ := parseLevel(cfg.LogLevel)
+	return level
+}
+
+// DoubleChanceMarginRat returns the Double Chance margin. It assumes the
+// config has been validated.
+func (cfg Config) DoubleChanceMarginRat() *big.Rat {
+	margin, _ := parseMargin(cfg.DoubleChanceMargin)
+	return margin
+}
+
+func parseMargin(s string) (*big.Rat, error) {
+	margin, ok := new(big.Rat).SetString(s)
+	if !ok || margin.Sign() < 0 || margin.Cmp(big.NewRat(1, 1)) >= 0 {
+		return new(big.Rat), fmt.Errorf("double_chance_margin %q must be a decimal in [0, 1)", s)
+	}
+	return margin, nil
+}
+
+func parseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return slog.LevelInfo, fmt.Errorf("log level %q is not one of debug, info, warn, error", s)
+	}
+	return level, nil
+}
+
+func checkFile(what, path string) error {
+	if path == "" {
+		return fmt.Errorf("%s data path is not set", what)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%s data file: %v", what, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s data path %s is a directory", what, path)
+	}
+	return nil
+}
+
+func setString(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
+
+func setDuration(dst *time.Duration, value string) error {
+	if value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*dst = d
+	return nil
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -21,9 +21,205 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/data": {
+            "get": {
+                "description": "Reports the data files currently loaded for each sport, with their SHA-256 hash, modification time, load time and the last reload error, if any",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get loaded data files",
+                "responses": {
+                    "200": {
+                        "description": "Status of every watched data file",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/watcher.FileStatus"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/events/{id}/{kind}": {
+            "get": {
+                "description": "Returns an event previously stored through the upload endpoint",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get an uploaded event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sport type (volleyball, cricket)",
+                        "name": "sport_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID (prematch event_id / result id)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "prematch",
+                            "result"
+                        ],
+                        "type": "string",
+                        "description": "Feed the event belongs to",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The stored event",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown sport_type or kind",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "No event uploaded under this ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Stores a bet365-shaped prematch or result response holding a single event under the given event ID. Uploaded events take precedence over events loaded from the data files.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Upload an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sport type (volleyball, cricket)",
+                        "name": "sport_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID (prematch event_id / result id)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "prematch",
+                            "result"
+                        ],
+                        "type": "string",
+                        "description": "Feed the event belongs to",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "bet365 response with exactly one event in results",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The stored event",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown sport_type or kind, or invalid event data",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes an uploaded event. Events loaded from the data files become visible again.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete an uploaded event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sport type (volleyball, cricket)",
+                        "name": "sport_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID (prematch event_id / result id)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "prematch",
+                            "result"
+                        ],
+                        "type": "string",
+                        "description": "Feed the event belongs to",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted"
+                    },
+                    "400": {
+                        "description": "Unknown sport_type or kind",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "No event uploaded under this ID",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
         "/evaluate": {
             "post": {
-                "description": "Evaluates a specific betting selection against the match results",
+                "description": "Evaluates a specific betting selection against the match results. When a stake is given the result also carries returns, profit and effective odds.",
                 "consumes": [
                     "application/json"
                 ],
@@ -35,6 +231,13 @@ const docTemplate = `{
                 ],
                 "summary": "Evaluate a betting selection",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sport type (volleyball, cricket)",
+                        "name": "sport_type",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "description": "Bet selection to evaluate",
                         "name": "request",
@@ -53,13 +256,105 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or parameters",
+                        "description": "Unknown sport_type, invalid request body or parameters",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "No result data available for the event",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/evaluate/accumulator": {
+            "post": {
+                "description": "Evaluates every leg with its sport's evaluator and settles them as one bet. A lost leg loses the bet; void and push legs count as odds of 1.0.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Evaluate an accumulator",
+                "parameters": [
+                    {
+                        "description": "Accumulator legs and optional stake",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AccumulatorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-leg results with the overall outcome and payout",
+                        "schema": {
+                            "$ref": "#/definitions/models.AccumulatorResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, leg or stake",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "404": {
+                        "description": "No data available for a leg's event",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/evaluate/system": {
+            "post": {
+                "description": "Expands the legs into every line of a full-cover system (trixie, patent, yankee, lucky_15, canadian/super_yankee, lucky_31, heinz, lucky_63, super_heinz, goliath), settles each line as an accumulator at the unit stake and totals them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Evaluate a system bet",
+                "parameters": [
+                    {
+                        "description": "System type, legs and unit stake",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SystemBetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-leg and per-line results with totals",
+                        "schema": {
+                            "$ref": "#/definitions/models.SystemBetResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, system, leg or stake",
                         "schema": {
                             "type": "object"
                         }
                     },
                     "404": {
-                        "description": "No result data available",
+                        "description": "No data available for a leg's event",
                         "schema": {
                             "type": "object"
                         }
@@ -80,6 +375,27 @@ const docTemplate = `{
                     "Selections"
                 ],
                 "summary": "Get available betting selections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sport type (volleyball, cricket)",
+                        "name": "sport_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID (required when more than one event is loaded)",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "bet365 fixture ID, alternative to event_id",
+                        "name": "FI",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "List of available selections grouped by market",
@@ -90,6 +406,12 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Unknown sport_type or missing/conflicting event_id/FI",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
                     "404": {
                         "description": "No prematch data available",
                         "schema": {
@@ -101,29 +423,105 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "models.AccumulatorLeg": {
+            "type": "object",
+            "properties": {
+                "FI": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "handicap": {
+                    "type": "string"
+                },
+                "header": {
+                    "type": "string"
+                },
+                "market": {
+                    "type": "string"
+                },
+                "odds": {
+                    "type": "string"
+                },
+                "score_line": {
+                    "type": "string"
+                },
+                "selection": {
+                    "type": "string"
+                },
+                "sport_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AccumulatorRequest": {
+            "description": "Request body for evaluating an accumulator (parlay)",
+            "type": "object",
+            "properties": {
+                "legs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AccumulatorLeg"
+                    }
+                },
+                "stake": {
+                    "description": "Stake is optional. When given, the result includes returns and profit.",
+                    "type": "string",
+                    "example": "10.00"
+                }
+            }
+        },
+        "models.AccumulatorResult": {
+            "description": "Result of evaluating an accumulator. CombinedOdds is the product of the leg prices as placed; EffectiveOdds is what was actually paid per unit staked after void, push and half results.",
+            "type": "object",
+            "properties": {
+                "combined_odds": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "effective_odds": {
+                    "type": "string"
+                },
+                "legs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LegResult"
+                    }
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "profit": {
+                    "type": "string"
+                },
+                "returns": {
+                    "type": "string"
+                },
+                "stake": {
+                    "type": "string"
+                }
+            }
+        },
         "models.AvailableSelection": {
-            "description": "Market with available betting options",
             "type": "object",
             "properties": {
                 "market": {
                     "type": "string"
                 },
+                "reason": {
+                    "type": "string"
+                },
                 "selections": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "handicap": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            },
-                            "odds": {
-                                "type": "number"
-                            }
-                        }
+                        "$ref": "#/definitions/models.SelectionOption"
                     }
+                },
+                "unavailable": {
+                    "type": "boolean"
                 }
             }
         },
@@ -131,33 +529,59 @@ const docTemplate = `{
             "description": "Request body for evaluating a betting selection",
             "type": "object",
             "properties": {
+                "FI": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
                 "handicap": {
                     "type": "string"
                 },
+                "header": {
+                    "type": "string"
+                },
                 "market": {
                     "type": "string"
                 },
+                "odds": {
+                    "description": "Odds prices markets the feed does not carry, such as in-play specials.\nIt is ignored for markets priced from the prematch data.",
+                    "type": "string"
+                },
                 "score_line": {
                     "description": "Add this for correct score",
                     "type": "string"
                 },
                 "selection": {
                     "type": "string"
+                },
+                "stake": {
+                    "description": "Stake is optional. When given, the result includes returns and profit.",
+                    "type": "string",
+                    "example": "10.00"
                 }
             }
         },
         "models.BetSelection": {
-            "description": "Concrete betting selection with all required parameters",
             "type": "object",
             "properties": {
+                "FI": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
                 "handicap": {
                     "type": "string"
                 },
+                "header": {
+                    "type": "string"
+                },
                 "market": {
                     "type": "string"
                 },
                 "odds": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "score_line": {
                     "type": "string"
@@ -174,14 +598,208 @@ const docTemplate = `{
                 "actual_result": {
                     "type": "string"
                 },
+                "dead_heat": {
+                    "description": "DeadHeat is the number of selections that tied when Outcome is\ndead-heat.",
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "effective_odds": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "profit": {
+                    "type": "string"
+                },
+                "returns": {
+                    "type": "string"
+                },
+                "selection": {
+                    "$ref": "#/definitions/models.BetSelection"
+                },
+                "stake": {
+                    "description": "Settlement amounts, only present when the request has a stake. Money is\nrounded half-up to 2 decimal places.",
+                    "type": "string"
+                },
+                "stats": {
+                    "description": "Stats are the match stats of the result, for sports whose feed has\nthem."
+                }
+            }
+        },
+        "models.LegResult": {
+            "type": "object",
+            "properties": {
+                "actual_result": {
+                    "type": "string"
+                },
+                "dead_heat": {
+                    "description": "DeadHeat is the number of selections that tied when Outcome is\ndead-heat.",
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
+                "effective_odds": {
+                    "type": "string"
+                },
                 "outcome": {
                     "type": "string"
                 },
+                "profit": {
+                    "type": "string"
+                },
+                "returns": {
+                    "type": "string"
+                },
                 "selection": {
                     "$ref": "#/definitions/models.BetSelection"
+                },
+                "sport_type": {
+                    "type": "string"
+                },
+                "stake": {
+                    "description": "Settlement amounts, only present when the request has a stake. Money is\nrounded half-up to 2 decimal places.",
+                    "type": "string"
+                },
+                "stats": {
+                    "description": "Stats are the match stats of the result, for sports whose feed has\nthem."
+                }
+            }
+        },
+        "models.SelectionOption": {
+            "type": "object",
+            "properties": {
+                "handicap": {
+                    "type": "string"
+                },
+                "header": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "odds": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SystemBetRequest": {
+            "description": "Request body for evaluating a full-cover system bet such as a Yankee. The number of legs must match the system.",
+            "type": "object",
+            "properties": {
+                "legs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AccumulatorLeg"
+                    }
+                },
+                "system": {
+                    "type": "string",
+                    "example": "yankee"
+                },
+                "unit_stake": {
+                    "type": "string",
+                    "example": "1.00"
+                }
+            }
+        },
+        "models.SystemBetResult": {
+            "description": "Result of evaluating a system bet, with every line settled as an accumulator at the unit stake",
+            "type": "object",
+            "properties": {
+                "legs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LegResult"
+                    }
+                },
+                "line_count": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SystemLine"
+                    }
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "profit": {
+                    "type": "string"
+                },
+                "returns": {
+                    "type": "string"
+                },
+                "system": {
+                    "type": "string"
+                },
+                "total_stake": {
+                    "type": "string"
+                },
+                "unit_stake": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SystemLine": {
+            "type": "object",
+            "properties": {
+                "effective_odds": {
+                    "type": "string"
+                },
+                "legs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "odds": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "returns": {
+                    "type": "string"
+                },
+                "stake": {
+                    "type": "string"
+                }
+            }
+        },
+        "watcher.FileStatus": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_error_at": {
+                    "type": "string"
+                },
+                "loaded_at": {
+                    "type": "string"
+                },
+                "modified_at": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "sport": {
+                    "type": "string"
                 }
             }
         }
@@ -0,0 +1,134 @@
+// Package eventstore keeps events uploaded through the admin API. Uploaded
+// events are overlaid on the data loaded from files, replacing file events
+// with the same ID.
+package eventstore
+
+import (
+	"fmt"
+	"sync"
+
+	"bet365-fiber-sim/models"
+)
+
+// Store holds events of one type keyed by event ID. The zero value is ready
+// to use.
+type Store[T any] struct {
+	mu     sync.RWMutex
+	events map[string]T
+	order  []string
+}
+
+// Put stores event under id, replacing any previous upload.
+func (s *Store[T]) Put(id string, event T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.events == nil {
+		s.events = make(map[string]T)
+	}
+	if _, ok := s.events[id]; !ok {
+		s.order = append(s.order, id)
+	}
+	s.events[id] = event
+}
+
+// Get returns the event stored under id.
+func (s *Store[T]) Get(id string) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	event, ok := s.events[id]
+	return event, ok
+}
+
+// Delete removes the event stored under id and reports whether there was
+// one.
+func (s *Store[T]) Delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.events[id]; !ok {
+		return false
+	}
+	delete(s.events, id)
+	for i, stored := range s.order {
+		if stored == id {
+			s.order = append(s.order[:i:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Overlay returns base with every stored event applied: a base event whose ID
+// is stored is replaced in place and the remaining stored events are appended
+// in upload order. base itself is not modified.
+func (s *Store[T]) Overlay(base []T, id func(T) string) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.events) == 0 {
+		return base
+	}
+
+	merged := make([]T, 0, len(base)+len(s.events))
+	replaced := make(map[string]bool, len(s.events))
+	for _, event := range base {
+		if stored, ok := s.events[id(event)]; ok {
+			merged = append(merged, stored)
+			replaced[id(event)] = true
+			continue
+		}
+		merged = append(merged, event)
+	}
+	for _, key := range s.order {
+		if !replaced[key] {
+			merged = append(merged, s.events[key])
+		}
+	}
+	return merged
+}
+
+// Single returns the only event of a decoded bet365 response. Uploads carry
+// exactly one event.
+func Single[T any](results []T) (T, error) {
+	var event T
+	if len(results) != 1 {
+		return event, fmt.Errorf("%w: expected exactly one event in results, got %d", models.ErrInvalidEvent, len(results))
+	}
+	return results[0], nil
+}
+
+// Feed describes the uploads of one bet365 feed: where its events are
+// stored and how an uploaded body is decoded and keyed.
+type Feed[T any] struct {
+	Store *Store[T]
+	// Decode parses a bet365 response and returns its events.
+	Decode func(raw []byte) ([]T, error)
+	// Key points at the ID field of an event, named Field in errors.
+	Key   func(event *T) *string
+	Field string
+}
+
+// Upload decodes body, which must hold a single event, and stores it under
+// id. An event without an ID takes id; one with a different ID is rejected.
+func (f Feed[T]) Upload(id string, body []byte) (T, error) {
+	var event T
+	events, err := f.Decode(body)
+	if err != nil {
+		return event, fmt.Errorf("%w: %v", models.ErrInvalidEvent, err)
+	}
+	event, err = Single(events)
+	if err != nil {
+		return event, err
+	}
+
+	key := f.Key(&event)
+	if *key == "" {
+		*key = id
+	} else if *key != id {
+		return event, fmt.Errorf("%w: %s %q does not match %q", models.ErrInvalidEvent, f.Field, *key, id)
+	}
+	f.Store.Put(id, event)
+	return event, nil
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"bet365-fiber-sim/models"
+	"bet365-fiber-sim/settlement"
+	"bet365-fiber-sim/sports"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Summary Evaluate an accumulator
+// @Description Evaluates every leg with its sport's evaluator and settles them as one bet. A lost leg loses the bet; void and push legs count as odds of 1.0.
+// @Tags Evaluation
+// @Accept json
+// @Produce json
+// @Param request body models.AccumulatorRequest true "Accumulator legs and optional stake"
+// @Success 200 {object} models.AccumulatorResult "Per-leg results with the overall outcome and payout"
+// @Failure 400 {object} object "Invalid request body, leg or stake"
+// @Failure 404 {object} object "No data available for a leg's event"
+// @Router /evaluate/accumulator [post]
+func EvaluateAccumulator(c *fiber.Ctx) error {
+	var req models.AccumulatorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if len(req.Legs) < 2 {
+		return eventError(c, fmt.Errorf("%w: an accumulator needs at least 2 legs, got %d", models.ErrInvalidBet, len(req.Legs)))
+	}
+
+	stake, err := parseOptionalStake(req.Stake)
+	if err != nil {
+		return eventError(c, err)
+	}
+
+	legs, err := evaluateLegs(req.Legs)
+	if err != nil {
+		return eventError(c, err)
+	}
+
+	combined, err := settlement.Accumulate(legResults(legs))
+	if err != nil {
+		return eventError(c, err)
+	}
+
+	result := models.AccumulatorResult{
+		Legs:          legs,
+		Outcome:       combined.Outcome,
+		CombinedOdds:  settlement.FormatOdds(combined.Odds),
+		EffectiveOdds: settlement.FormatOdds(combined.Multiplier),
+		Description:   describeLegs(legs),
+	}
+	if stake != nil {
+		result.Stake, result.Returns, result.Profit = settlement.Amounts(stake, combined.Multiplier)
+	}
+
+	return c.JSON(result)
+}
+
+// evaluateLegs prices and settles every leg with its own sport. Errors name
+// the leg they came from.
+func evaluateLegs(legs []models.AccumulatorLeg) ([]models.LegResult, error) {
+	results := make([]models.LegResult, 0, len(legs))
+	for i, leg := range legs {
+		sport, ok := sports.Get(leg.SportType)
+		if !ok {
+			return nil, fmt.Errorf("leg %d: unknown sport_type %q (supported: %s)", i+1, leg.SportType, strings.Join(sports.Names(), ", "))
+		}
+
+		selection, err := sport.BuildSelection(leg.EvaluationRequest())
+		if err != nil {
+			return nil, fmt.Errorf("leg %d: %w", i+1, err)
+		}
+		result, err := sport.Evaluate(selection)
+		if err != nil {
+			return nil, fmt.Errorf("leg %d: %w", i+1, err)
+		}
+
+		results = append(results, models.LegResult{SportType: leg.SportType, EvaluationResult: result})
+	}
+	return results, nil
+}
+
+func legResults(legs []models.LegResult) []models.EvaluationResult {
+	results := make([]models.EvaluationResult, len(legs))
+	for i, leg := range legs {
+		results[i] = leg.EvaluationResult
+	}
+	return results
+}
+
+// describeLegs summarises leg outcomes, e.g. "3 legs: 2 won, 1 void".
+func describeLegs(legs []models.LegResult) string {
+	order := []string{
+		models.OutcomeWon,
+		models.OutcomeHalfWon,
+		models.OutcomeDeadHeat,
+		models.OutcomePush,
+		models.OutcomeVoid,
+		models.OutcomeHalfLost,
+		models.OutcomeLost,
+	}
+	counts := make(map[string]int)
+	for _, leg := range legs {
+		counts[leg.Outcome]++
+	}
+
+	var parts []string
+	for _, outcome := range order {
+		if counts[outcome] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[outcome], outcome))
+		}
+	}
+	return fmt.Sprintf("%d legs: %s", len(legs), strings.Join(parts, ", "))
+}
+
+func parseOptionalStake(stake json.Number) (*big.Rat, error) {
+	if stake == "" {
+		return nil, nil
+	}
+	return settlement.ParseStake(stake)
+}
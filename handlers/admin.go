@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"fmt"
+
+	"bet365-fiber-sim/models"
+	"bet365-fiber-sim/sports"
+	"bet365-fiber-sim/watcher"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Summary Get loaded data files
+// @Description Reports the data files currently loaded for each sport, with their SHA-256 hash, modification time, load time and the last reload error, if any
+// @Tags Admin
+// @Produce json
+// @Success 200 {array} watcher.FileStatus "Status of every watched data file"
+// @Router /admin/data [get]
+func GetDataStatus(w *watcher.Watcher) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(w.Status())
+	}
+}
+
+// @Summary Upload an event
+// @Description Stores a bet365-shaped prematch or result response holding a single event under the given event ID. Uploaded events take precedence over events loaded from the data files.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param sport_type query string true "Sport type (volleyball, cricket)"
+// @Param id path string true "Event ID (prematch event_id / result id)"
+// @Param kind path string true "Feed the event belongs to" Enums(prematch, result)
+// @Param request body object true "bet365 response with exactly one event in results"
+// @Success 201 {object} object "The stored event"
+// @Failure 400 {object} object "Unknown sport_type or kind, or invalid event data"
+// @Router /admin/events/{id}/{kind} [post]
+func UploadEvent(c *fiber.Ctx) error {
+	sport, ok := sports.Get(c.Query("sport_type"))
+	if !ok {
+		return unknownSport(c)
+	}
+	kind, ok := eventKind(c)
+	if !ok {
+		return unknownKind(c)
+	}
+
+	event, err := sport.StoreEvent(kind, c.Params("id"), c.Body())
+	if err != nil {
+		return eventError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(event)
+}
+
+// @Summary Get an uploaded event
+// @Description Returns an event previously stored through the upload endpoint
+// @Tags Admin
+// @Produce json
+// @Param sport_type query string true "Sport type (volleyball, cricket)"
+// @Param id path string true "Event ID (prematch event_id / result id)"
+// @Param kind path string true "Feed the event belongs to" Enums(prematch, result)
+// @Success 200 {object} object "The stored event"
+// @Failure 400 {object} object "Unknown sport_type or kind"
+// @Failure 404 {object} object "No event uploaded under this ID"
+// @Router /admin/events/{id}/{kind} [get]
+func GetUploadedEvent(c *fiber.Ctx) error {
+	sport, ok := sports.Get(c.Query("sport_type"))
+	if !ok {
+		return unknownSport(c)
+	}
+	kind, ok := eventKind(c)
+	if !ok {
+		return unknownKind(c)
+	}
+
+	event, ok := sport.StoredEvent(kind, c.Params("id"))
+	if !ok {
+		return eventError(c, fmt.Errorf("%w: no uploaded %s event %q", models.ErrEventNotFound, kind, c.Params("id")))
+	}
+	return c.JSON(event)
+}
+
+// @Summary Delete an uploaded event
+// @Description Removes an uploaded event. Events loaded from the data files become visible again.
+// @Tags Admin
+// @Param sport_type query string true "Sport type (volleyball, cricket)"
+// @Param id path string true "Event ID (prematch event_id / result id)"
+// @Param kind path string true "Feed the event belongs to" Enums(prematch, result)
+// @Success 204 "Deleted"
+// @Failure 400 {object} object "Unknown sport_type or kind"
+// @Failure 404 {object} object "No event uploaded under this ID"
+// @Router /admin/events/{id}/{kind} [delete]
+func DeleteUploadedEvent(c *fiber.Ctx) error {
+	sport, ok := sports.Get(c.Query("sport_type"))
+	if !ok {
+		return unknownSport(c)
+	}
+	kind, ok := eventKind(c)
+	if !ok {
+		return unknownKind(c)
+	}
+
+	if !sport.DeleteStoredEvent(kind, c.Params("id")) {
+		return eventError(c, fmt.Errorf("%w: no uploaded %s event %q", models.ErrEventNotFound, kind, c.Params("id")))
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func eventKind(c *fiber.Ctx) (sports.Kind, bool) {
+	kind := sports.Kind(c.Params("kind"))
+	return kind, kind == sports.Prematch || kind == sports.Result
+}
+
+func unknownKind(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": fmt.Sprintf("unknown event kind %q, expected %q or %q", c.Params("kind"), sports.Prematch, sports.Result),
+	})
+}
@@ -1,11 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+
 	models "bet365-fiber-sim/models"
-	cricket_models "bet365-fiber-sim/models/cricket"
-	volleyball_models "bet365-fiber-sim/models/volleyball"
-	cricket_utils "bet365-fiber-sim/utils/cricket"
-	volleyball_utils "bet365-fiber-sim/utils/volleyball"
+	"bet365-fiber-sim/settlement"
+	"bet365-fiber-sim/sports"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -15,111 +16,93 @@ import (
 // @Tags Selections
 // @Accept  json
 // @Produce  json
+// @Param sport_type query string true "Sport type (volleyball, cricket)"
+// @Param event_id query string false "Event ID (required when more than one event is loaded)"
+// @Param FI query string false "bet365 fixture ID, alternative to event_id"
 // @Success 200 {array} models.AvailableSelection "List of available selections grouped by market"
+// @Failure 400 {object} object "Unknown sport_type or missing/conflicting event_id/FI"
 // @Failure 404 {object} object "No prematch data available"
 // @Router /selections [get]
 func GetAvailableSelections(c *fiber.Ctx) error {
+	sport, ok := sports.Get(c.Query("sport_type"))
+	if !ok {
+		return unknownSport(c)
+	}
 
-	var available []models.AvailableSelection
-	sport_type := c.Query("sport_type")
-
-	if sport_type == "volleyball" {
-		if len(volleyball_utils.PrematchData.Results) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "No prematch data available",
-			})
-		}
-
-		available = []models.AvailableSelection{
-			volleyball_utils.Get1X2Selections(),
-			volleyball_utils.GetTotalSelections(),
-			volleyball_utils.GetCorrectScoreSelections(),
-			volleyball_utils.GetDoubleChanceSelections(),
-		}
-
-		// return c.JSON(available)
-	} else if sport_type == "cricket" {
-		if len(cricket_utils.PrematchData.Results) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "No prematch data available",
-			})
-		}
-
-		availableTemp := []models.AvailableSelection{
-			cricket_utils.GetCricket1X2Selections(),
-			cricket_utils.GetCricketTotalRunsSelections(),
-			cricket_utils.GetCricketDoubleChanceSelections(),
-		}
-
-		available = availableTemp
-
+	available, err := sport.AvailableSelections(c.Query("event_id"), c.Query("FI"))
+	if err != nil {
+		return eventError(c, err)
 	}
 	return c.JSON(available)
 }
 
 // @Summary Evaluate a betting selection
-// @Description Evaluates a specific betting selection against the match results
+// @Description Evaluates a specific betting selection against the match results. When a stake is given the result also carries returns, profit and effective odds.
 // @Tags Evaluation
 // @Accept json
 // @Produce json
+// @Param sport_type query string true "Sport type (volleyball, cricket)"
 // @Param request body models.BetEvaluationRequest true "Bet selection to evaluate"
 // @Success 200 {object} models.EvaluationResult "Evaluation result with outcome"
-// @Failure 400 {object} object "Invalid request body or parameters"
-// @Failure 404 {object} object "No result data available"
+// @Failure 400 {object} object "Unknown sport_type, invalid request body or parameters"
+// @Failure 404 {object} object "No result data available for the event"
 // @Router /evaluate [post]
 func EvaluateCustomSelection(c *fiber.Ctx) error {
-	var req volleyball_models.BetEvaluationRequest
-	var result models.EvaluationResult
-	sport_type := c.Query("sport_type")
-
-	if sport_type == "volleyball" {
-		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid request body",
-			})
-		}
+	sport, ok := sports.Get(c.Query("sport_type"))
+	if !ok {
+		return unknownSport(c)
+	}
 
-		if len(volleyball_utils.ResultData.Results) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "No result data available",
-			})
-		}
+	var req models.BetEvaluationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
 
-		selection := volleyball_utils.CreateSelectionFromRequest(req)
-		if selection.Market == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid selection parameters",
-			})
-		}
+	stake, err := parseOptionalStake(req.Stake)
+	if err != nil {
+		return eventError(c, err)
+	}
 
-		result = volleyball_utils.EvaluateSelection(selection, volleyball_utils.ResultData)
-	} else if sport_type == "cricket" {
-		var req cricket_models.BetEvaluationRequest
-		if err := c.BodyParser(&req); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid request body",
-			})
-		}
+	selection, err := sport.BuildSelection(req)
+	if err != nil {
+		return eventError(c, err)
+	}
 
-		if len(cricket_utils.ResultData.Results) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "No result data available",
-			})
-		}
+	result, err := sport.Evaluate(selection)
+	if err != nil {
+		return eventError(c, err)
+	}
 
-		selection := cricket_utils.CreateCricketSelectionFromPrematch(cricket_utils.PrematchData, req.Market, req.Selection, req.Handicap)
-		if selection.Market == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid selection parameters",
-			})
+	if stake != nil {
+		if err := settlement.Apply(&result, stake); err != nil {
+			return eventError(c, err)
 		}
-
-		result = cricket_utils.EvaluateCricketSelection(selection, cricket_utils.ResultData)
 	}
 
 	return c.JSON(result)
 }
 
+// unknownSport rejects a missing or unregistered sport_type.
+func unknownSport(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":            fmt.Sprintf("unknown sport_type %q", c.Query("sport_type")),
+		"supported_sports": sports.Names(),
+	})
+}
+
+// eventError maps event lookup and selection errors to an HTTP response.
+func eventError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusBadRequest
+	if errors.Is(err, models.ErrEventNotFound) {
+		status = fiber.StatusNotFound
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
+
 // @Summary Get available cricket betting selections
 // @Description Retrieves all available cricket betting markets and selections from prematch data
 // @Tags Cricket Selections
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+
+	"bet365-fiber-sim/models"
+	"bet365-fiber-sim/settlement"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// @Summary Evaluate a system bet
+// @Description Expands the legs into every line of a full-cover system (trixie, patent, yankee, lucky_15, canadian/super_yankee, lucky_31, heinz, lucky_63, super_heinz, goliath), settles each line as an accumulator at the unit stake and totals them.
+// @Tags Evaluation
+// @Accept json
+// @Produce json
+// @Param request body models.SystemBetRequest true "System type, legs and unit stake"
+// @Success 200 {object} models.SystemBetResult "Per-leg and per-line results with totals"
+// @Failure 400 {object} object "Invalid request body, system, leg or stake"
+// @Failure 404 {object} object "No data available for a leg's event"
+// @Router /evaluate/system [post]
+func EvaluateSystemBet(c *fiber.Ctx) error {
+	var req models.SystemBetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	system, ok := settlement.LookupSystem(req.System)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":             fmt.Sprintf("unknown system %q", req.System),
+			"supported_systems": settlement.SystemNames(),
+		})
+	}
+	if len(req.Legs) != system.Selections {
+		return eventError(c, fmt.Errorf("%w: a %s needs %d legs, got %d",
+			models.ErrInvalidBet, system.Name, system.Selections, len(req.Legs)))
+	}
+
+	unit, err := settlement.ParseStake(req.UnitStake)
+	if err != nil {
+		return eventError(c, fmt.Errorf("unit_stake: %w", err))
+	}
+
+	legs, err := evaluateLegs(req.Legs)
+	if err != nil {
+		return eventError(c, err)
+	}
+
+	result, err := settlement.SettleSystem(system, legResults(legs), unit)
+	if err != nil {
+		return eventError(c, err)
+	}
+	result.Legs = legs
+
+	return c.JSON(result)
+}
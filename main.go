@@ -1,12 +1,17 @@
 package main
 
 import (
+	"bet365-fiber-sim/config"
+	"bet365-fiber-sim/pricing"
 	"bet365-fiber-sim/router"
+	"bet365-fiber-sim/sports"
 	"bet365-fiber-sim/utils"
 	cricket_utils "bet365-fiber-sim/utils/cricket"
-	volleyball_utils "bet365-fiber-sim/utils/volleyball"
+	_ "bet365-fiber-sim/utils/volleyball"
+	"bet365-fiber-sim/watcher"
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
 	"github.com/gofiber/fiber/v2"
@@ -21,13 +26,47 @@ import (
 // @host localhost:8080
 // @BasePath /api/v1/
 func main() {
+	cfg, err := config.Load(os.Args[1:], sports.Names())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.SlogLevel()})))
+	pricing.DoubleChanceMargin = cfg.DoubleChanceMarginRat()
+	cricket_utils.MatchTieRule = cricket_utils.TieRule(cfg.CricketTieRule)
+
+	w := watcher.New(cfg.ReloadInterval)
+	if err := watchData(w, cfg); err != nil {
+		slog.Error("failed to load data", "error", err)
+		os.Exit(1)
+	}
+	go w.Run(context.Background())
+
 	app := fiber.New()
 
-	volleyball_utils.InitHandlers(app)
-	cricket_utils.InitCricketHandlers()
-	utils.ConfigCORS(app)
+	utils.ConfigCORS(app, cfg.CORSOrigins)
 
-	router.SetupRoutes(app)
+	router.SetupRoutes(app, w)
+
+	if err := app.Listen(fmt.Sprintf(":%s", cfg.Port)); err != nil {
+		slog.Error("server stopped", "error", err)
+		os.Exit(1)
+	}
+}
 
-	log.Fatal(app.Listen(fmt.Sprintf(":%s", os.Getenv("INTERNAL_PORT"))))
+// watchData loads the prematch and result files of every registered sport and
+// keeps them up to date.
+func watchData(w *watcher.Watcher, cfg config.Config) error {
+	for _, sport := range sports.All() {
+		paths := cfg.Data[sport.Name()]
+		if err := w.Add(sport.Name(), "prematch", paths.Prematch, sport.LoadPrematch); err != nil {
+			return err
+		}
+		if err := w.Add(sport.Name(), "result", paths.Result, sport.LoadResults); err != nil {
+			return err
+		}
+		slog.Info("loaded data", "sport", sport.Name(), "prematch", paths.Prematch, "result", paths.Result)
+	}
+	return nil
 }
@@ -0,0 +1,91 @@
+package models
+
+import "encoding/json"
+
+// AccumulatorRequest represents the payload for evaluating an accumulator
+// @Description Request body for evaluating an accumulator (parlay)
+type AccumulatorRequest struct {
+	Legs []AccumulatorLeg `json:"legs"`
+	// Stake is optional. When given, the result includes returns and profit.
+	Stake json.Number `json:"stake,omitempty" swaggertype:"string" example:"10.00"`
+}
+
+// AccumulatorLeg is one selection of an accumulator. Each leg names its own
+// sport, so accumulators can mix sports.
+type AccumulatorLeg struct {
+	SportType string `json:"sport_type"`
+	EventID   string `json:"event_id,omitempty"`
+	FI        string `json:"FI,omitempty"`
+	Market    string `json:"market"`
+	Selection string `json:"selection"`
+	Header    string `json:"header,omitempty"`
+	Handicap  string `json:"handicap,omitempty"`
+	ScoreLine string `json:"score_line,omitempty"`
+	Odds      string `json:"odds,omitempty"`
+}
+
+// EvaluationRequest returns the single-selection request for the leg.
+func (leg AccumulatorLeg) EvaluationRequest() BetEvaluationRequest {
+	return BetEvaluationRequest{
+		EventID:   leg.EventID,
+		FI:        leg.FI,
+		Market:    leg.Market,
+		Selection: leg.Selection,
+		Header:    leg.Header,
+		Handicap:  leg.Handicap,
+		ScoreLine: leg.ScoreLine,
+		Odds:      leg.Odds,
+	}
+}
+
+// LegResult is the evaluation of one accumulator leg.
+type LegResult struct {
+	SportType string `json:"sport_type"`
+	EvaluationResult
+}
+
+// AccumulatorResult represents the outcome of an accumulator
+// @Description Result of evaluating an accumulator. CombinedOdds is the product of the leg prices as placed; EffectiveOdds is what was actually paid per unit staked after void, push and half results.
+type AccumulatorResult struct {
+	Legs          []LegResult `json:"legs"`
+	Outcome       string      `json:"outcome"`
+	CombinedOdds  string      `json:"combined_odds"`
+	EffectiveOdds string      `json:"effective_odds"`
+	Description   string      `json:"description"`
+	Stake         string      `json:"stake,omitempty"`
+	Returns       string      `json:"returns,omitempty"`
+	Profit        string      `json:"profit,omitempty"`
+}
+
+// SystemBetRequest represents the payload for evaluating a system bet
+// @Description Request body for evaluating a full-cover system bet such as a Yankee. The number of legs must match the system.
+type SystemBetRequest struct {
+	System    string           `json:"system" example:"yankee"`
+	Legs      []AccumulatorLeg `json:"legs"`
+	UnitStake json.Number      `json:"unit_stake" swaggertype:"string" example:"1.00"`
+}
+
+// SystemLine is one combination of a system bet. Legs holds the 1-based
+// positions of the legs it combines.
+type SystemLine struct {
+	Legs          []int  `json:"legs"`
+	Outcome       string `json:"outcome"`
+	Odds          string `json:"odds"`
+	EffectiveOdds string `json:"effective_odds"`
+	Stake         string `json:"stake"`
+	Returns       string `json:"returns"`
+}
+
+// SystemBetResult represents the outcome of a system bet
+// @Description Result of evaluating a system bet, with every line settled as an accumulator at the unit stake
+type SystemBetResult struct {
+	System     string       `json:"system"`
+	Legs       []LegResult  `json:"legs"`
+	Lines      []SystemLine `json:"lines"`
+	LineCount  int          `json:"line_count"`
+	Outcome    string       `json:"outcome"`
+	UnitStake  string       `json:"unit_stake"`
+	TotalStake string       `json:"total_stake"`
+	Returns    string       `json:"returns"`
+	Profit     string       `json:"profit"`
+}
@@ -1,8 +1,5 @@
 package cricket_models
 
-type BetEvaluationRequest struct {
-	Market    string `json:"market"`
-	Selection string `json:"selection"`
-	Handicap  string `json:"handicap,omitempty"`
-	ScoreLine string `json:"score_line,omitempty"`
-}
+import "bet365-fiber-sim/models"
+
+type BetEvaluationRequest = models.BetEvaluationRequest
@@ -1,38 +1,56 @@
 package cricket_models
 
 type PrematchResponse struct {
-    Success int `json:"success"`
-    Results []struct {
-        ID         string `json:"id"`
-        SportID    string `json:"sport_id"`
-        Time       int64  `json:"time"`
-        TimeStatus string `json:"time_status"`
-        League     struct {
-            ID   string `json:"id"`
-            Name string `json:"name"`
-            CC   string `json:"cc"`
-        } `json:"league"`
-        Home struct {
-            ID      string `json:"id"`
-            Name    string `json:"name"`
-            ImageID string `json:"image_id"`
-            CC      string `json:"cc"`
-        } `json:"home"`
-        Away struct {
-            ID      string `json:"id"`
-            Name    string `json:"name"`
-            ImageID string `json:"image_id"`
-            CC      string `json:"cc"`
-        } `json:"away"`
-        Markets []Market `json:"markets"`
-    } `json:"results"`
+	Success int             `json:"success"`
+	Results []PrematchEvent `json:"results"`
 }
 
+// PrematchEvent holds the markets offered for a single match. FI is the
+// bet365 fixture ID and EventID is the result feed ID.
+//
+// bet365 publishes cricket markets in named groups, each with an "sp" map of
+// markets keyed by a slug such as "to_win_the_match". The same market can
+// appear in several groups (often an empty placeholder in one and the priced
+// copy in "others").
+type PrematchEvent struct {
+	FI        string        `json:"FI"`
+	EventID   string        `json:"event_id"`
+	Main      MarketGroup   `json:"main"`
+	Match     MarketGroup   `json:"match"`
+	Player    MarketGroup   `json:"player"`
+	Team      MarketGroup   `json:"team"`
+	Innings1  MarketGroup   `json:"innings_1"`
+	FirstOver MarketGroup   `json:"1st_over"`
+	Others    []MarketGroup `json:"others"`
+	Schedule  struct {
+		UpdatedAt string `json:"updated_at"`
+		Key       string `json:"key"`
+		Sp        struct {
+			Main []Odd `json:"main"`
+		} `json:"sp"`
+	} `json:"schedule"`
+}
+
+type MarketGroup struct {
+	UpdatedAt string            `json:"updated_at"`
+	Key       string            `json:"key"`
+	Sp        map[string]Market `json:"sp"`
+}
 
 type Market struct {
-    Name     string `json:"name"`
-    Header   string `json:"header"`
-    Odds     string `json:"odds"`
-    Handicap string `json:"handicap"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Odds []Odd  `json:"odds"`
 }
 
+// Odd is a single entry of a market. Entries whose ID starts with "PC" carry
+// no price; they label the rows of a grid whose prices follow as separate
+// entries (see cricket_utils.ResolveOdds).
+type Odd struct {
+	ID       string `json:"id"`
+	Odds     string `json:"odds"`
+	Name     string `json:"name"`
+	Name2    string `json:"name2,omitempty"`
+	Header   string `json:"header"`
+	Handicap string `json:"handicap"`
+}
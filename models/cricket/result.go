@@ -40,39 +40,199 @@ package cricket_models
 // }
 
 type ResultResponse struct {
-	Success int `json:"success"`
-	Results []struct {
-		ID         string `json:"id"`
-		SportID    string `json:"sport_id"`
-		Time       string `json:"time"`
-		TimeStatus string `json:"time_status"`
-		League     struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-			CC   string `json:"cc"`
-		} `json:"league"`
-		Home struct {
-			ID      string `json:"id"`
-			Name    string `json:"name"`
-			ImageID string `json:"image_id"`
-			CC      string `json:"cc"`
-		} `json:"home"`
-		Away struct {
-			ID      string `json:"id"`
-			Name    string `json:"name"`
-			ImageID string `json:"image_id"`
-			CC      string `json:"cc"`
-		} `json:"away"`
-		SS    string `json:"ss"`
-		Extra struct {
-			StadiumData struct {
-				ID           string `json:"id"`
-				Name         string `json:"name"`
-				City         string `json:"city"`
-				Country      string `json:"country"`
-				Capacity     string `json:"capacity"`
-				GoogleCoords string `json:"googlecoords"`
-			} `json:"stadium_data"`
-		} `json:"extra"`
-	} `json:"results"`
+	Success int           `json:"success"`
+	Results []ResultEvent `json:"results"`
+}
+
+// ResultEvent is a single settled match. ID joins to the prematch event_id
+// and Bet365ID joins to the prematch FI.
+type ResultEvent struct {
+	ID         string `json:"id"`
+	SportID    string `json:"sport_id"`
+	Time       string `json:"time"`
+	TimeStatus string `json:"time_status"`
+	League     struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		CC   string `json:"cc"`
+	} `json:"league"`
+	Home struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		ImageID string `json:"image_id"`
+		CC      string `json:"cc"`
+	} `json:"home"`
+	Away struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		ImageID string `json:"image_id"`
+		CC      string `json:"cc"`
+	} `json:"away"`
+	SS    string `json:"ss"`
+	Extra struct {
+		StadiumData struct {
+			ID           string `json:"id"`
+			Name         string `json:"name"`
+			City         string `json:"city"`
+			Country      string `json:"country"`
+			Capacity     string `json:"capacity"`
+			GoogleCoords string `json:"googlecoords"`
+		} `json:"stadium_data"`
+	} `json:"extra"`
+	Bet365ID string `json:"bet365_id"`
+
+	// The fields below are not part of the bet365 result. They are only
+	// present when the data file or an upload adds them.
+
+	// Innings lists the innings in batting order.
+	Innings []Innings `json:"innings,omitempty"`
+	// SuperOver is set when a tied match was decided by a super over.
+	SuperOver *SuperOver `json:"super_over,omitempty"`
+	// Scorecard is the player breakdown used to settle player markets.
+	Scorecard *Scorecard `json:"scorecard,omitempty"`
+	// Deliveries is the ball-by-ball record in the order bowled.
+	Deliveries []Delivery `json:"deliveries,omitempty"`
+	// Aggregates holds each team's match totals for the match specials.
+	Aggregates []TeamAggregates `json:"aggregates,omitempty"`
+}
+
+// TeamAggregates are one team's totals for the match. Team is "1" for the
+// home team and "2" for the away team. Fours, Sixes, Fifties and Hundreds
+// count the team's batting; an innings of 100 or more is a hundred and not
+// also a fifty. RunOuts and Catches are the dismissals the team effected in
+// the field. MaxOverRuns and MaxOverBoundaries are the most runs and
+// boundaries (fours and sixes) the team scored in a single over.
+type TeamAggregates struct {
+	Team              string `json:"team"`
+	Fours             int    `json:"fours"`
+	Sixes             int    `json:"sixes"`
+	RunOuts           int    `json:"run_outs"`
+	Catches           int    `json:"catches"`
+	Fifties           int    `json:"fifties"`
+	Hundreds          int    `json:"hundreds"`
+	MaxOverRuns       int    `json:"max_over_runs"`
+	MaxOverBoundaries int    `json:"max_over_boundaries"`
+}
+
+// Delivery is one ball bowled. Team is the batting team, "1" for the home
+// team and "2" for the away team. Over counts from 1 within the innings and
+// Ball is the legal ball of the over (1-6); a wide or no ball shares the
+// number of the legal ball that follows it. Runs are off the bat and Extras
+// are any wides, no balls, byes or leg byes, with ExtraType naming them
+// ("wide", "no_ball", "bye", "leg_bye"). Wicket is the dismissal kind, e.g.
+// "caught", and empty when no wicket fell.
+type Delivery struct {
+	Innings   int    `json:"innings"`
+	Team      string `json:"team"`
+	Over      int    `json:"over"`
+	Ball      int    `json:"ball"`
+	Batter    string `json:"batter"`
+	Bowler    string `json:"bowler"`
+	Runs      int    `json:"runs"`
+	Extras    int    `json:"extras,omitempty"`
+	ExtraType string `json:"extra_type,omitempty"`
+	Wicket    string `json:"wicket,omitempty"`
+}
+
+// Legal reports whether the delivery counts towards the over.
+func (d Delivery) Legal() bool {
+	return d.ExtraType != "wide" && d.ExtraType != "no_ball"
+}
+
+// Total returns the runs scored off the delivery, extras included.
+func (d Delivery) Total() int {
+	return d.Runs + d.Extras
+}
+
+// Scorecard lists every batter and bowler of the match. Team is "1" for the
+// home team and "2" for the away team.
+type Scorecard struct {
+	Batting          []BatterScore   `json:"batting"`
+	Bowling          []BowlerFigures `json:"bowling"`
+	PlayerOfTheMatch string          `json:"player_of_the_match,omitempty"`
+}
+
+type BatterScore struct {
+	Name  string `json:"name"`
+	Team  string `json:"team"`
+	Runs  int    `json:"runs"`
+	Balls int    `json:"balls"`
+	Fours int    `json:"fours"`
+	Sixes int    `json:"sixes"`
+}
+
+type BowlerFigures struct {
+	Name    string `json:"name"`
+	Team    string `json:"team"`
+	Overs   string `json:"overs"`
+	Runs    int    `json:"runs"`
+	Wickets int    `json:"wickets"`
+}
+
+// SuperOver is the outcome of a super over. Winner is "1" for the home team
+// and "2" for the away team; the runs are optional.
+type SuperOver struct {
+	Winner   string `json:"winner"`
+	HomeRuns int    `json:"home_runs,omitempty"`
+	AwayRuns int    `json:"away_runs,omitempty"`
+}
+
+// Innings is one team's innings. Team is "1" for the home team and "2" for
+// the away team; Overs is in overs.balls notation, e.g. "16.1". RunsAtOver
+// holds the cumulative runs at the end of each completed over, starting with
+// the first. FallOfWickets lists the wickets in the order they fell.
+type Innings struct {
+	Team          string         `json:"team"`
+	Runs          int            `json:"runs"`
+	Wickets       int            `json:"wickets"`
+	Overs         string         `json:"overs"`
+	RunsAtOver    []int          `json:"runs_at_over,omitempty"`
+	FallOfWickets []FallOfWicket `json:"fall_of_wickets,omitempty"`
+}
+
+// FallOfWicket is one wicket of an innings: the team score when it fell, the
+// over.ball it fell on, the batter out and how ("caught", "bowled", "lbw",
+// "run_out", "stumped", "hit_wicket", ...).
+type FallOfWicket struct {
+	Wicket int    `json:"wicket"`
+	Runs   int    `json:"runs"`
+	Over   string `json:"over"`
+	Batter string `json:"batter"`
+	Method string `json:"method"`
+}
+
+// Score is a parsed "ss" score: each team's innings in the order they were
+// batted.
+type Score struct {
+	Home []InningsScore `json:"home"`
+	Away []InningsScore `json:"away"`
+}
+
+// InningsScore is one innings of a score such as "117/10 (16.1)". Wickets is
+// nil when the score does not give them, and Overs and Balls are zero when it
+// has no overs.
+type InningsScore struct {
+	Runs     int  `json:"runs"`
+	Wickets  *int `json:"wickets,omitempty"`
+	Overs    int  `json:"overs"`
+	Balls    int  `json:"balls"`
+	Declared bool `json:"declared,omitempty"`
+}
+
+// HomeRuns returns the home team's runs across all its innings.
+func (s Score) HomeRuns() int {
+	return totalRuns(s.Home)
+}
+
+// AwayRuns returns the away team's runs across all its innings.
+func (s Score) AwayRuns() int {
+	return totalRuns(s.Away)
+}
+
+func totalRuns(innings []InningsScore) int {
+	runs := 0
+	for _, i := range innings {
+		runs += i.Runs
+	}
+	return runs
 }
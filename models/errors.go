@@ -0,0 +1,28 @@
+package models
+
+import "errors"
+
+var (
+	// ErrEventRequired is returned when more than one event is loaded and the
+	// request does not say which one it is for.
+	ErrEventRequired = errors.New("event_id or FI is required when more than one event is loaded")
+	// ErrEventNotFound is returned when no loaded event matches the requested ID.
+	ErrEventNotFound = errors.New("event not found")
+	// ErrEventMismatch is returned when event_id and FI point at different events.
+	ErrEventMismatch = errors.New("event_id and FI refer to different events")
+	// ErrInvalidSelection is returned when a market/selection pair cannot be
+	// found in the prematch data.
+	ErrInvalidSelection = errors.New("invalid selection parameters")
+	// ErrMarketUnavailable is returned when a market cannot be offered for
+	// an event, e.g. Double Chance on a two-way match.
+	ErrMarketUnavailable = errors.New("market unavailable")
+	// ErrInvalidEvent is returned when an uploaded event cannot be decoded or
+	// does not match the ID it is uploaded under.
+	ErrInvalidEvent = errors.New("invalid event data")
+	// ErrInvalidStake is returned when a stake is not a positive decimal.
+	ErrInvalidStake = errors.New("invalid stake")
+	// ErrInvalidOdds is returned when a selection's odds cannot be settled.
+	ErrInvalidOdds = errors.New("invalid odds")
+	// ErrInvalidBet is returned when a multi-selection bet is malformed.
+	ErrInvalidBet = errors.New("invalid bet")
+)
@@ -0,0 +1,34 @@
+package models
+
+import "fmt"
+
+// FindEvent returns the event of events addressed by eventID and/or fi,
+// using id and fiOf to read an event's keys. When neither is given the only
+// event is used. It is an error if eventID and fi match different events.
+func FindEvent[T any](events []T, eventID, fi string, id, fiOf func(*T) string) (*T, error) {
+	if eventID == "" && fi == "" {
+		switch len(events) {
+		case 0:
+			return nil, ErrEventNotFound
+		case 1:
+			return &events[0], nil
+		default:
+			return nil, ErrEventRequired
+		}
+	}
+
+	for i := range events {
+		event := &events[i]
+		idMatch := eventID != "" && id(event) == eventID
+		fiMatch := fi != "" && fiOf(event) == fi
+		if !idMatch && !fiMatch {
+			continue
+		}
+		if (eventID != "" && !idMatch) || (fi != "" && !fiMatch) {
+			return nil, ErrEventMismatch
+		}
+		return event, nil
+	}
+
+	return nil, fmt.Errorf("%w: event_id=%q FI=%q", ErrEventNotFound, eventID, fi)
+}
@@ -1,12 +1,41 @@
 package models
 
+import "encoding/json"
+
+// BetEvaluationRequest represents the payload for evaluating a bet
+// @Description Request body for evaluating a betting selection
+type BetEvaluationRequest struct {
+	EventID   string `json:"event_id,omitempty"`
+	FI        string `json:"FI,omitempty"`
+	Market    string `json:"market"`
+	Selection string `json:"selection"`
+	Header    string `json:"header,omitempty"`
+	Handicap  string `json:"handicap,omitempty"`
+	ScoreLine string `json:"score_line,omitempty"` // Add this for correct score
+	// Odds prices markets the feed does not carry, such as in-play specials.
+	// It is ignored for markets priced from the prematch data.
+	Odds string `json:"odds,omitempty"`
+	// Stake is optional. When given, the result includes returns and profit.
+	Stake json.Number `json:"stake,omitempty" swaggertype:"string" example:"10.00"`
+}
+
+// AvailableSelection is a market with its priced selections. A market that
+// cannot be offered for the event is still listed, marked Unavailable with
+// the Reason.
 type AvailableSelection struct {
-	Market     string `json:"market"`
-	Selections []struct {
-		Name     string `json:"name"`
-		Odds     string `json:"odds"`
-		Handicap string `json:"handicap,omitempty"`
-	} `json:"selections"`
+	Market      string            `json:"market"`
+	Selections  []SelectionOption `json:"selections"`
+	Unavailable bool              `json:"unavailable,omitempty"`
+	Reason      string            `json:"reason,omitempty"`
+}
+
+// SelectionOption is one priced outcome of a market. Header is set for
+// markets where the name alone is ambiguous, e.g. Over/Under rows.
+type SelectionOption struct {
+	Name     string `json:"name"`
+	Header   string `json:"header,omitempty"`
+	Odds     string `json:"odds"`
+	Handicap string `json:"handicap,omitempty"`
 }
 
 // EvaluationResult represents the outcome of a bet evaluation
@@ -16,11 +45,26 @@ type EvaluationResult struct {
 	ActualResult string       `json:"actual_result"`
 	Outcome      string       `json:"outcome"`
 	Description  string       `json:"description"`
+	// Stats are the match stats of the result, for sports whose feed has
+	// them.
+	Stats any `json:"stats,omitempty"`
+	// DeadHeat is the number of selections that tied when Outcome is
+	// dead-heat.
+	DeadHeat int `json:"dead_heat,omitempty"`
+	// Settlement amounts, only present when the request has a stake. Money is
+	// rounded half-up to 2 decimal places.
+	Stake         string `json:"stake,omitempty"`
+	Returns       string `json:"returns,omitempty"`
+	Profit        string `json:"profit,omitempty"`
+	EffectiveOdds string `json:"effective_odds,omitempty"`
 }
 
 type BetSelection struct {
+	EventID   string `json:"event_id,omitempty"`
+	FI        string `json:"FI,omitempty"`
 	Market    string `json:"market"`
 	Selection string `json:"selection"`
+	Header    string `json:"header,omitempty"`
 	Odds      string `json:"odds"`
 	Handicap  string `json:"handicap,omitempty"`
 	ScoreLine string `json:"score_line,omitempty"`
@@ -0,0 +1,17 @@
+package models
+
+// Outcomes an evaluation can settle with.
+const (
+	OutcomeWon      = "won"
+	OutcomeLost     = "lost"
+	OutcomePush     = "push"
+	OutcomeVoid     = "void"
+	OutcomeHalfWon  = "half-won"
+	OutcomeHalfLost = "half-lost"
+	// OutcomeDeadHeat is a winning selection that tied with others; the
+	// stake is divided between them (see EvaluationResult.DeadHeat).
+	OutcomeDeadHeat = "dead-heat"
+	// OutcomePartial is only used for combined bets that return something,
+	// but less than the stake.
+	OutcomePartial = "partial"
+)
@@ -1,13 +1,9 @@
 package volleyball_models
 
+import "bet365-fiber-sim/models"
+
 // BetEvaluationRequest represents the payload for evaluating a bet
-// @Description Request body for evaluating a betting selection
-type BetEvaluationRequest struct {
-	Market    string `json:"market"`
-	Selection string `json:"selection"`
-	Handicap  string `json:"handicap,omitempty"`
-	ScoreLine string `json:"score_line,omitempty"` // Add this for correct score
-}
+type BetEvaluationRequest = models.BetEvaluationRequest
 
 // BetSelection represents a concrete betting selection
 // @Description Concrete betting selection with all required parameters
@@ -1,51 +1,44 @@
 package volleyball_models
 
 type PrematchResponse struct {
-	Success int `json:"success"`
-	Results []struct {
-		FI      string `json:"FI"`
-		EventID string `json:"event_id"`
-		Main    struct {
-			UpdatedAt string `json:"updated_at"`
-			Key       string `json:"key"`
-			Sp        struct {
-				GameLines struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"game_lines"`
-				CorrectSetScore struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"correct_set_score"`
-				// Other markets can be added here
-			} `json:"sp"`
-		} `json:"main"`
-		Others []struct {
-			UpdatedAt string `json:"updated_at"`
-			Sp        struct {
-				Set1Lines struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"set_1_lines"`
-				// Other sub-markets can be added here
-			} `json:"sp"`
-		} `json:"others"`
-		Schedule struct {
-			UpdatedAt string `json:"updated_at"`
-			Key       string `json:"key"`
-			Sp        struct {
-				Main []struct {
-					ID       string `json:"id"`
-					Odds     string `json:"odds"`
-					Name     string `json:"name"`
-					Handicap string `json:"handicap"`
-				} `json:"main"`
-			} `json:"sp"`
-		} `json:"schedule"`
-	} `json:"results"`
+	Success int             `json:"success"`
+	Results []PrematchEvent `json:"results"`
+}
+
+// PrematchEvent holds the markets offered for a single match. FI is the
+// bet365 fixture ID and EventID is the result feed ID.
+type PrematchEvent struct {
+	FI      string `json:"FI"`
+	EventID string `json:"event_id"`
+	Main    struct {
+		UpdatedAt string `json:"updated_at"`
+		Key       string `json:"key"`
+		Sp        struct {
+			GameLines       Market `json:"game_lines"`
+			CorrectSetScore Market `json:"correct_set_score"`
+			DoubleChance    Market `json:"double_chance"`
+			// Other markets can be added here
+		} `json:"sp"`
+	} `json:"main"`
+	// Others holds the markets bet365 publishes outside "main", e.g.
+	// set_1_lines or match_total_odd_even, keyed by their feed slug.
+	Others []struct {
+		UpdatedAt string            `json:"updated_at"`
+		Sp        map[string]Market `json:"sp"`
+	} `json:"others"`
+	Schedule struct {
+		UpdatedAt string `json:"updated_at"`
+		Key       string `json:"key"`
+		Sp        struct {
+			Main []Odd `json:"main"`
+		} `json:"sp"`
+	} `json:"schedule"`
+}
+
+type Market struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Odds []Odd  `json:"odds"`
 }
 
 type Odd struct {
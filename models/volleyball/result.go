@@ -6,14 +6,44 @@ type SetScore struct {
 }
 
 type ResultResponse struct {
-	Success int `json:"success"`
-	Results []struct {
-		// ... other fields ...
-		SS     string `json:"ss"`
-		Scores map[string]struct {
-			Home string `json:"home"`
-			Away string `json:"away"`
-		} `json:"scores"`
-		// ... other fields ...
-	} `json:"results"`
+	Success int           `json:"success"`
+	Results []ResultEvent `json:"results"`
+}
+
+// ResultEvent is a single settled match. ID joins to the prematch event_id
+// and Bet365ID joins to the prematch FI.
+type ResultEvent struct {
+	ID       string `json:"id"`
+	Bet365ID string `json:"bet365_id"`
+	Home     Team   `json:"home"`
+	Away     Team   `json:"away"`
+	// ... other fields ...
+	SS     string              `json:"ss"`
+	Scores map[string]SetScore `json:"scores"`
+	Extra  struct {
+		BestOfSets string `json:"bestofsets"`
+	} `json:"extra"`
+	// Stats is nil when the feed has no match stats.
+	Stats *Stats `json:"stats,omitempty"`
+	// Events is the in-play timeline, e.g. "Set 1 - Race to 10 points - <team>".
+	Events []TimelineEntry `json:"events"`
+	// ... other fields ...
+}
+
+type Team struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	ImageID string `json:"image_id"`
+	CC      string `json:"cc"`
+}
+
+type TimelineEntry struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// Stats holds match stats as [home, away] pairs.
+type Stats struct {
+	PointsWonOnServe []string `json:"points_won_on_serve,omitempty"`
+	LongestStreak    []string `json:"longest_streak,omitempty"`
 }
@@ -0,0 +1,116 @@
+// Package pricing derives prices for markets the feed does not always carry.
+package pricing
+
+import (
+	"fmt"
+	"math/big"
+
+	"bet365-fiber-sim/models"
+	"bet365-fiber-sim/settlement"
+)
+
+// DoubleChanceMargin is the bookmaker margin added when Double Chance prices
+// are derived from 1X2 prices. It is set from the config at startup.
+var DoubleChanceMargin = big.NewRat(5, 100)
+
+// doubleChanceLegs lists each Double Chance selection with the two 1X2
+// outcomes it covers.
+var doubleChanceLegs = []struct {
+	Name     string
+	Outcomes [2]string
+}{
+	{"1X", [2]string{"1", "X"}},
+	{"12", [2]string{"1", "2"}},
+	{"X2", [2]string{"X", "2"}},
+}
+
+// DoubleChanceMarket builds the Double Chance market of an event. Prices are
+// taken from the feed when it has them (feed is non-empty); otherwise they
+// are derived from the 1X2 prices in winner. When winner has no draw price
+// the sport is two-way for this event and the market is unavailable.
+func DoubleChanceMarket(feed, winner []models.SelectionOption) models.AvailableSelection {
+	market := models.AvailableSelection{
+		Market:     "Double Chance",
+		Selections: []models.SelectionOption{},
+	}
+
+	if len(feed) > 0 {
+		market.Selections = feed
+		return market
+	}
+
+	prices := make(map[string]string, len(winner))
+	for _, option := range winner {
+		prices[option.Name] = option.Odds
+	}
+	if prices["X"] == "" {
+		market.Unavailable = true
+		market.Reason = "no draw price: Double Chance needs a three-way 1X2 market"
+		return market
+	}
+
+	selections, err := DeriveDoubleChance(prices["1"], prices["X"], prices["2"], DoubleChanceMargin)
+	if err != nil {
+		market.Unavailable = true
+		market.Reason = err.Error()
+		return market
+	}
+	market.Selections = selections
+	return market
+}
+
+// DeriveDoubleChance prices 1X, 12 and X2 from 1X2 prices. The 1X2 implied
+// probabilities are normalised to remove their overround, summed per
+// selection and the margin is applied on top:
+//
+//	price = 1 / ((p(a) + p(b)) * (1 + margin))
+//
+// Prices are rounded half-up to 2 decimal places and never drop below 1.01.
+func DeriveDoubleChance(home, draw, away string, margin *big.Rat) ([]models.SelectionOption, error) {
+	odds := map[string]string{"1": home, "X": draw, "2": away}
+
+	implied := make(map[string]*big.Rat, len(odds))
+	book := new(big.Rat)
+	for outcome, price := range odds {
+		o, err := settlement.ParseOdds(price)
+		if err != nil {
+			return nil, fmt.Errorf("1X2 price for %s: %w", outcome, err)
+		}
+		implied[outcome] = new(big.Rat).Inv(o)
+		book.Add(book, implied[outcome])
+	}
+
+	loading := new(big.Rat).Add(big.NewRat(1, 1), margin)
+	floor := big.NewRat(101, 100)
+
+	selections := make([]models.SelectionOption, 0, len(doubleChanceLegs))
+	for _, leg := range doubleChanceLegs {
+		p := new(big.Rat).Add(implied[leg.Outcomes[0]], implied[leg.Outcomes[1]])
+		p.Quo(p, book)
+		p.Mul(p, loading)
+
+		price := settlement.RoundHalfUp(new(big.Rat).Inv(p), 2)
+		if price.Cmp(floor) < 0 {
+			price = floor
+		}
+		selections = append(selections, models.SelectionOption{
+			Name: leg.Name,
+			Odds: price.FloatString(2),
+		})
+	}
+	return selections, nil
+}
+
+// FindSelection returns the named selection of market, failing if the market
+// is unavailable or has no such selection.
+func FindSelection(market models.AvailableSelection, name string) (models.SelectionOption, error) {
+	if market.Unavailable {
+		return models.SelectionOption{}, fmt.Errorf("%w: %s: %s", models.ErrMarketUnavailable, market.Market, market.Reason)
+	}
+	for _, option := range market.Selections {
+		if option.Name == name {
+			return option, nil
+		}
+	}
+	return models.SelectionOption{}, models.ErrInvalidSelection
+}
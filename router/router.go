@@ -2,6 +2,7 @@ package router
 
 import (
 	"bet365-fiber-sim/handlers"
+	"bet365-fiber-sim/watcher"
 
 	_ "bet365-fiber-sim/docs"
 
@@ -9,7 +10,7 @@ import (
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 )
 
-func SetupRoutes(app *fiber.App) {
+func SetupRoutes(app *fiber.App, w *watcher.Watcher) {
 	api := app.Group("/api/v1")
 
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -20,7 +21,15 @@ func SetupRoutes(app *fiber.App) {
 
 	app.Get("/docs/*", fiberSwagger.WrapHandler)
 	api.Post("/evaluate", handlers.EvaluateCustomSelection)
+	api.Post("/evaluate/accumulator", handlers.EvaluateAccumulator)
+	api.Post("/evaluate/system", handlers.EvaluateSystemBet)
 	api.Get("/selections", handlers.GetAvailableSelections)
+
+	admin := api.Group("/admin")
+	admin.Get("/data", handlers.GetDataStatus(w))
+	admin.Post("/events/:id/:kind", handlers.UploadEvent)
+	admin.Get("/events/:id/:kind", handlers.GetUploadedEvent)
+	admin.Delete("/events/:id/:kind", handlers.DeleteUploadedEvent)
 	// app.Get("/cricket/selections", handlers.GetAvailableCricketSelections)
 	// app.Post("/cricket/evaluate", handlers.EvaluateCricketSelection)
 
@@ -0,0 +1,196 @@
+// Package settlement turns evaluation outcomes into money.
+//
+// All arithmetic is done on exact rationals (math/big.Rat); odds and stakes
+// are never converted to float64. Amounts are rounded once, at the end,
+// half-up to 2 decimal places, and profit is derived from the rounded
+// returns so that stake + profit always equals returns.
+package settlement
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"bet365-fiber-sim/models"
+)
+
+var (
+	one  = big.NewRat(1, 1)
+	half = big.NewRat(1, 2)
+)
+
+// ParseStake parses a stake, which must be a positive amount with at most 2
+// decimal places.
+func ParseStake(stake json.Number) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(string(stake))
+	if !ok || r.Sign() <= 0 || RoundHalfUp(r, 2).Cmp(r) != 0 {
+		return nil, fmt.Errorf("%w: %q must be a positive amount with at most 2 decimal places", models.ErrInvalidStake, string(stake))
+	}
+	return r, nil
+}
+
+// ParseOdds parses decimal odds, which must be at least 1.
+func ParseOdds(odds string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(odds)
+	if !ok || r.Cmp(one) < 0 {
+		return nil, fmt.Errorf("%w: %q is not a decimal price of at least 1", models.ErrInvalidOdds, odds)
+	}
+	return r, nil
+}
+
+// Multiplier returns what one unit staked at odds returns for outcome:
+//
+//	won        odds
+//	lost       0
+//	push, void 1 (stake refunded)
+//	half-won   (odds + 1) / 2 (half wins, half refunded)
+//	half-lost  1/2 (half lost, half refunded)
+func Multiplier(odds *big.Rat, outcome string) (*big.Rat, error) {
+	switch outcome {
+	case models.OutcomeWon:
+		return new(big.Rat).Set(odds), nil
+	case models.OutcomeLost:
+		return new(big.Rat), nil
+	case models.OutcomePush, models.OutcomeVoid:
+		return new(big.Rat).Set(one), nil
+	case models.OutcomeHalfWon:
+		m := new(big.Rat).Add(odds, one)
+		return m.Mul(m, half), nil
+	case models.OutcomeHalfLost:
+		return new(big.Rat).Set(half), nil
+	}
+	return nil, fmt.Errorf("cannot settle unknown outcome %q", outcome)
+}
+
+// DeadHeatMultiplier returns what one unit staked at odds returns when the
+// selection ties with others for a win: the stake is divided between the
+// places selections, each part winning at full odds and the rest lost.
+func DeadHeatMultiplier(odds *big.Rat, places int) *big.Rat {
+	if places < 2 {
+		places = 2
+	}
+	return new(big.Rat).Quo(odds, big.NewRat(int64(places), 1))
+}
+
+// Apply fills in the settlement amounts of result for stake.
+func Apply(result *models.EvaluationResult, stake *big.Rat) error {
+	m, err := settlementMultiplier(*result)
+	if err != nil {
+		return err
+	}
+	Fill(result, stake, m)
+	return nil
+}
+
+// Fill sets the settlement fields of result from the stake and the return
+// multiplier.
+func Fill(result *models.EvaluationResult, stake, multiplier *big.Rat) {
+	result.Stake, result.Returns, result.Profit = Amounts(stake, multiplier)
+	result.EffectiveOdds = FormatOdds(multiplier)
+}
+
+// Amounts returns the formatted stake, returns and profit of stake settled
+// at multiplier.
+func Amounts(stake, multiplier *big.Rat) (string, string, string) {
+	returns := RoundHalfUp(new(big.Rat).Mul(stake, multiplier), 2)
+	profit := new(big.Rat).Sub(returns, stake)
+	return FormatMoney(stake), FormatMoney(returns), FormatMoney(profit)
+}
+
+// Combined is the settlement of several legs taken as one bet.
+type Combined struct {
+	// Odds is the product of the leg prices as placed.
+	Odds *big.Rat
+	// Multiplier is what one unit staked returns.
+	Multiplier *big.Rat
+	Outcome    string
+}
+
+// Accumulate settles legs as an accumulator. The legs' multipliers are
+// multiplied together, so a lost leg loses the bet and push and void legs
+// count as odds of 1.0.
+func Accumulate(legs []models.EvaluationResult) (Combined, error) {
+	combined := Combined{
+		Odds:       new(big.Rat).Set(one),
+		Multiplier: new(big.Rat).Set(one),
+	}
+
+	allVoid := true
+	for i, leg := range legs {
+		m, err := settlementMultiplier(leg)
+		if err != nil {
+			return Combined{}, fmt.Errorf("leg %d: %w", i+1, err)
+		}
+		combined.Multiplier.Mul(combined.Multiplier, m)
+
+		// Unpriced legs can only be void or refunded, so they do not
+		// change the placed odds.
+		if odds, err := ParseOdds(leg.Selection.Odds); err == nil {
+			combined.Odds.Mul(combined.Odds, odds)
+		}
+		if leg.Outcome != models.OutcomeVoid {
+			allVoid = false
+		}
+	}
+
+	switch cmp := combined.Multiplier.Cmp(one); {
+	case allVoid:
+		combined.Outcome = models.OutcomeVoid
+	case cmp > 0:
+		combined.Outcome = models.OutcomeWon
+	case cmp == 0:
+		combined.Outcome = models.OutcomePush
+	case combined.Multiplier.Sign() == 0:
+		combined.Outcome = models.OutcomeLost
+	default:
+		combined.Outcome = models.OutcomePartial
+	}
+	return combined, nil
+}
+
+// settlementMultiplier skips parsing the odds for outcomes that refund or
+// lose the stake, so that void selections without a price still settle.
+func settlementMultiplier(result models.EvaluationResult) (*big.Rat, error) {
+	switch result.Outcome {
+	case models.OutcomeLost, models.OutcomePush, models.OutcomeVoid, models.OutcomeHalfLost:
+		return Multiplier(one, result.Outcome)
+	}
+	o, err := ParseOdds(result.Selection.Odds)
+	if err != nil {
+		return nil, err
+	}
+	if result.Outcome == models.OutcomeDeadHeat {
+		return DeadHeatMultiplier(o, result.DeadHeat), nil
+	}
+	return Multiplier(o, result.Outcome)
+}
+
+// RoundHalfUp rounds r to the given number of decimal places, with halves
+// rounded away from zero.
+func RoundHalfUp(r *big.Rat, places int) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(scale))
+
+	num := new(big.Int).Abs(scaled.Num())
+	q, m := new(big.Int).QuoRem(num, scaled.Denom(), new(big.Int))
+	if m.Lsh(m, 1).Cmp(scaled.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if scaled.Sign() < 0 {
+		q.Neg(q)
+	}
+	return new(big.Rat).SetFrac(q, scale)
+}
+
+// FormatMoney formats r rounded half-up to 2 decimal places.
+func FormatMoney(r *big.Rat) string {
+	return RoundHalfUp(r, 2).FloatString(2)
+}
+
+// FormatOdds formats r rounded half-up to 3 decimal places, dropping
+// trailing zeros beyond the second.
+func FormatOdds(r *big.Rat) string {
+	s := RoundHalfUp(r, 3).FloatString(3)
+	return strings.TrimSuffix(s, "0")
+}
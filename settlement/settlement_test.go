@@ -0,0 +1,162 @@
+package settlement
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"bet365-fiber-sim/models"
+)
+
+func rat(t *testing.T, s string) *big.Rat {
+	t.Helper()
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		t.Fatalf("bad rational %q", s)
+	}
+	return r
+}
+
+func TestRoundHalfUp(t *testing.T) {
+	tests := []struct {
+		value  string
+		places int
+		want   string
+	}{
+		{"1.005", 2, "1.01"},
+		{"1.004", 2, "1.00"},
+		{"2.675", 2, "2.68"},
+		{"-1.005", 2, "-1.01"},
+		{"-1.004", 2, "-1.00"},
+		{"10/3", 2, "3.33"},
+		{"20/3", 2, "6.67"},
+		{"1.0005", 3, "1.001"},
+		{"7.65", 2, "7.65"},
+		{"0", 2, "0.00"},
+	}
+
+	for _, tt := range tests {
+		got := RoundHalfUp(rat(t, tt.value), tt.places).FloatString(tt.places)
+		if got != tt.want {
+			t.Errorf("RoundHalfUp(%s, %d) = %s, want %s", tt.value, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestMultiplier(t *testing.T) {
+	tests := []struct {
+		odds    string
+		outcome string
+		want    string
+	}{
+		{"1.90", models.OutcomeWon, "1.90"},
+		{"1.90", models.OutcomeLost, "0"},
+		{"1.90", models.OutcomePush, "1"},
+		{"1.90", models.OutcomeVoid, "1"},
+		{"1.90", models.OutcomeHalfWon, "1.45"},
+		{"2.15", models.OutcomeHalfWon, "1.575"},
+		{"1.90", models.OutcomeHalfLost, "0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.outcome+" at "+tt.odds, func(t *testing.T) {
+			got, err := Multiplier(rat(t, tt.odds), tt.outcome)
+			if err != nil {
+				t.Fatalf("Multiplier: %v", err)
+			}
+			if got.Cmp(rat(t, tt.want)) != 0 {
+				t.Errorf("Multiplier(%s, %s) = %s, want %s", tt.odds, tt.outcome, got.FloatString(3), tt.want)
+			}
+		})
+	}
+
+	if _, err := Multiplier(rat(t, "1.90"), "unknown"); err == nil {
+		t.Error("Multiplier with an unknown outcome did not fail")
+	}
+}
+
+func TestDeadHeatMultiplier(t *testing.T) {
+	tests := []struct {
+		odds   string
+		places int
+		want   string
+	}{
+		{"1.53", 2, "0.765"},
+		{"9.00", 3, "3"},
+		{"4.00", 4, "1"},
+		{"3.00", 0, "1.5"},
+	}
+
+	for _, tt := range tests {
+		got := DeadHeatMultiplier(rat(t, tt.odds), tt.places)
+		if got.Cmp(rat(t, tt.want)) != 0 {
+			t.Errorf("DeadHeatMultiplier(%s, %d) = %s, want %s", tt.odds, tt.places, got.FloatString(3), tt.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name          string
+		odds          string
+		outcome       string
+		deadHeat      int
+		stake         string
+		wantReturns   string
+		wantProfit    string
+		wantEffective string
+	}{
+		{"won", "1.53", models.OutcomeWon, 0, "10", "15.30", "5.30", "1.53"},
+		{"lost", "1.53", models.OutcomeLost, 0, "10", "0.00", "-10.00", "0.00"},
+		{"void without a price", "", models.OutcomeVoid, 0, "10", "10.00", "0.00", "1.00"},
+		{"half-won", "1.90", models.OutcomeHalfWon, 0, "10", "14.50", "4.50", "1.45"},
+		{"half-lost", "1.90", models.OutcomeHalfLost, 0, "10", "5.00", "-5.00", "0.50"},
+		{"dead heat of two", "1.53", models.OutcomeDeadHeat, 2, "10", "7.65", "-2.35", "0.765"},
+		{"dead heat of three rounds half up", "2.05", models.OutcomeDeadHeat, 3, "1", "0.68", "-0.32", "0.683"},
+		{"half-won rounds half up", "1.01", models.OutcomeHalfWon, 0, "0.01", "0.01", "0.00", "1.005"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := models.EvaluationResult{
+				Selection: models.BetSelection{Odds: tt.odds},
+				Outcome:   tt.outcome,
+				DeadHeat:  tt.deadHeat,
+			}
+			if err := Apply(&result, rat(t, tt.stake)); err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if result.Returns != tt.wantReturns {
+				t.Errorf("Returns = %s, want %s", result.Returns, tt.wantReturns)
+			}
+			if result.Profit != tt.wantProfit {
+				t.Errorf("Profit = %s, want %s", result.Profit, tt.wantProfit)
+			}
+			if result.EffectiveOdds != tt.wantEffective {
+				t.Errorf("EffectiveOdds = %s, want %s", result.EffectiveOdds, tt.wantEffective)
+			}
+		})
+	}
+}
+
+func TestParseStake(t *testing.T) {
+	tests := []struct {
+		stake string
+		ok    bool
+	}{
+		{"10", true},
+		{"10.50", true},
+		{"0.01", true},
+		{"10.005", false},
+		{"0", false},
+		{"-5", false},
+		{"ten", false},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseStake(json.Number(tt.stake))
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseStake(%q) error = %v, want ok %v", tt.stake, err, tt.ok)
+		}
+	}
+}
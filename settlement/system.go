@@ -0,0 +1,145 @@
+package settlement
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"bet365-fiber-sim/models"
+)
+
+// System is a full-cover system bet: every combination of its selections
+// from MinSize up to all of them is a separate line.
+type System struct {
+	Name       string
+	Selections int
+	MinSize    int
+}
+
+var systems = map[string]System{
+	"trixie":       {Name: "Trixie", Selections: 3, MinSize: 2},
+	"patent":       {Name: "Patent", Selections: 3, MinSize: 1},
+	"yankee":       {Name: "Yankee", Selections: 4, MinSize: 2},
+	"lucky_15":     {Name: "Lucky 15", Selections: 4, MinSize: 1},
+	"canadian":     {Name: "Canadian", Selections: 5, MinSize: 2},
+	"super_yankee": {Name: "Super Yankee", Selections: 5, MinSize: 2},
+	"lucky_31":     {Name: "Lucky 31", Selections: 5, MinSize: 1},
+	"heinz":        {Name: "Heinz", Selections: 6, MinSize: 2},
+	"lucky_63":     {Name: "Lucky 63", Selections: 6, MinSize: 1},
+	"super_heinz":  {Name: "Super Heinz", Selections: 7, MinSize: 2},
+	"goliath":      {Name: "Goliath", Selections: 8, MinSize: 2},
+}
+
+// LookupSystem finds a system by name. Case, spaces and hyphens are ignored,
+// so "Lucky 15", "lucky-15" and "lucky_15" are the same system.
+func LookupSystem(name string) (System, bool) {
+	key := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+	system, ok := systems[key]
+	return system, ok
+}
+
+// SystemNames returns the sorted keys of all known systems.
+func SystemNames() []string {
+	names := make([]string, 0, len(systems))
+	for name := range systems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lines returns the 0-based leg indices of every line of the system, from
+// the smallest combinations up.
+func (s System) Lines() [][]int {
+	var lines [][]int
+	for size := s.MinSize; size <= s.Selections; size++ {
+		lines = append(lines, combinations(s.Selections, size)...)
+	}
+	return lines
+}
+
+// combinations returns every k-element subset of 0..n-1 in lexicographic
+// order.
+func combinations(n, k int) [][]int {
+	var out [][]int
+	combo := make([]int, k)
+	var build func(start, depth int)
+	build = func(start, depth int) {
+		if depth == k {
+			out = append(out, append([]int(nil), combo...))
+			return
+		}
+		for i := start; i <= n-(k-depth); i++ {
+			combo[depth] = i
+			build(i+1, depth+1)
+		}
+	}
+	build(0, 0)
+	return out
+}
+
+// SettleSystem settles legs as the given system with unit staked on every
+// line. Each line is settled as an accumulator and rounded on its own; the
+// totals are the sums of the lines.
+func SettleSystem(system System, legs []models.EvaluationResult, unit *big.Rat) (models.SystemBetResult, error) {
+	if len(legs) != system.Selections {
+		return models.SystemBetResult{}, fmt.Errorf("%w: a %s needs %d legs, got %d",
+			models.ErrInvalidBet, system.Name, system.Selections, len(legs))
+	}
+
+	result := models.SystemBetResult{System: system.Name}
+	totalStake := new(big.Rat)
+	totalReturns := new(big.Rat)
+	allVoid := true
+
+	for _, line := range system.Lines() {
+		lineLegs := make([]models.EvaluationResult, len(line))
+		positions := make([]int, len(line))
+		for i, leg := range line {
+			lineLegs[i] = legs[leg]
+			positions[i] = leg + 1
+		}
+
+		combined, err := Accumulate(lineLegs)
+		if err != nil {
+			return models.SystemBetResult{}, err
+		}
+		if combined.Outcome != models.OutcomeVoid {
+			allVoid = false
+		}
+
+		returns := RoundHalfUp(new(big.Rat).Mul(unit, combined.Multiplier), 2)
+		totalStake.Add(totalStake, unit)
+		totalReturns.Add(totalReturns, returns)
+
+		result.Lines = append(result.Lines, models.SystemLine{
+			Legs:          positions,
+			Outcome:       combined.Outcome,
+			Odds:          FormatOdds(combined.Odds),
+			EffectiveOdds: FormatOdds(combined.Multiplier),
+			Stake:         FormatMoney(unit),
+			Returns:       FormatMoney(returns),
+		})
+	}
+
+	result.LineCount = len(result.Lines)
+	result.UnitStake = FormatMoney(unit)
+	result.TotalStake = FormatMoney(totalStake)
+	result.Returns = FormatMoney(totalReturns)
+	result.Profit = FormatMoney(new(big.Rat).Sub(totalReturns, totalStake))
+
+	switch cmp := totalReturns.Cmp(totalStake); {
+	case allVoid:
+		result.Outcome = models.OutcomeVoid
+	case cmp > 0:
+		result.Outcome = models.OutcomeWon
+	case cmp == 0:
+		result.Outcome = models.OutcomePush
+	case totalReturns.Sign() == 0:
+		result.Outcome = models.OutcomeLost
+	default:
+		result.Outcome = models.OutcomePartial
+	}
+	return result, nil
+}
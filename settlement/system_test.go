@@ -0,0 +1,175 @@
+package settlement
+
+import (
+	"errors"
+	"testing"
+
+	"bet365-fiber-sim/models"
+)
+
+func TestSystemLines(t *testing.T) {
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"Trixie", 4},
+		{"Patent", 7},
+		{"Yankee", 11},
+		{"Lucky 15", 15},
+		{"Canadian", 26},
+		{"super-yankee", 26},
+		{"Lucky 31", 31},
+		{"Heinz", 57},
+		{"Lucky 63", 63},
+		{"Super Heinz", 120},
+		{"GOLIATH", 247},
+	}
+
+	for _, tt := range tests {
+		system, ok := LookupSystem(tt.name)
+		if !ok {
+			t.Errorf("LookupSystem(%q) found nothing", tt.name)
+			continue
+		}
+		if got := len(system.Lines()); got != tt.want {
+			t.Errorf("%s has %d lines, want %d", system.Name, got, tt.want)
+		}
+	}
+
+	if _, ok := LookupSystem("lucky 7"); ok {
+		t.Error(`LookupSystem("lucky 7") found a system`)
+	}
+}
+
+func TestSystemLinesOrder(t *testing.T) {
+	system, _ := LookupSystem("trixie")
+	want := [][]int{{0, 1}, {0, 2}, {1, 2}, {0, 1, 2}}
+
+	got := system.Lines()
+	if len(got) != len(want) {
+		t.Fatalf("Trixie lines = %v, want %v", got, want)
+	}
+	for i := range want {
+		if len(got[i]) != len(want[i]) {
+			t.Fatalf("Trixie lines = %v, want %v", got, want)
+		}
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Fatalf("Trixie lines = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func leg(odds, outcome string) models.EvaluationResult {
+	return models.EvaluationResult{Selection: models.BetSelection{Odds: odds}, Outcome: outcome}
+}
+
+func TestSettleSystem(t *testing.T) {
+	tests := []struct {
+		name        string
+		system      string
+		legs        []models.EvaluationResult
+		unit        string
+		wantOutcome string
+		wantStake   string
+		wantReturns string
+		wantProfit  string
+	}{
+		{
+			name:   "trixie with one loser keeps the winning double",
+			system: "trixie",
+			legs: []models.EvaluationResult{
+				leg("2.00", models.OutcomeWon), leg("3.00", models.OutcomeWon), leg("4.00", models.OutcomeLost),
+			},
+			unit:        "1",
+			wantOutcome: models.OutcomeWon,
+			wantStake:   "4.00",
+			wantReturns: "6.00",
+			wantProfit:  "2.00",
+		},
+		{
+			name:   "patent with a void leg settles it at 1.0",
+			system: "patent",
+			legs: []models.EvaluationResult{
+				leg("2.00", models.OutcomeWon), leg("5.00", models.OutcomeVoid), leg("3.00", models.OutcomeLost),
+			},
+			unit:        "1",
+			wantOutcome: models.OutcomePartial,
+			wantStake:   "7.00",
+			wantReturns: "5.00",
+			wantProfit:  "-2.00",
+		},
+		{
+			// Each double returns 0.1776889 and the treble 0.2368593:
+			// rounded per line they total 0.78, unrounded 0.77.
+			name:   "every line rounds on its own",
+			system: "trixie",
+			legs: []models.EvaluationResult{
+				leg("1.333", models.OutcomeWon), leg("1.333", models.OutcomeWon), leg("1.333", models.OutcomeWon),
+			},
+			unit:        "0.10",
+			wantOutcome: models.OutcomeWon,
+			wantStake:   "0.40",
+			wantReturns: "0.78",
+			wantProfit:  "0.38",
+		},
+		{
+			name:   "all void refunds every line",
+			system: "trixie",
+			legs: []models.EvaluationResult{
+				leg("", models.OutcomeVoid), leg("", models.OutcomeVoid), leg("", models.OutcomeVoid),
+			},
+			unit:        "2.50",
+			wantOutcome: models.OutcomeVoid,
+			wantStake:   "10.00",
+			wantReturns: "10.00",
+			wantProfit:  "0.00",
+		},
+		{
+			name:   "all lost",
+			system: "trixie",
+			legs: []models.EvaluationResult{
+				leg("2.00", models.OutcomeLost), leg("2.00", models.OutcomeLost), leg("2.00", models.OutcomeWon),
+			},
+			unit:        "1",
+			wantOutcome: models.OutcomeLost,
+			wantStake:   "4.00",
+			wantReturns: "0.00",
+			wantProfit:  "-4.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			system, _ := LookupSystem(tt.system)
+			got, err := SettleSystem(system, tt.legs, rat(t, tt.unit))
+			if err != nil {
+				t.Fatalf("SettleSystem: %v", err)
+			}
+			if got.LineCount != len(system.Lines()) {
+				t.Errorf("LineCount = %d, want %d", got.LineCount, len(system.Lines()))
+			}
+			if got.Outcome != tt.wantOutcome {
+				t.Errorf("Outcome = %q, want %q", got.Outcome, tt.wantOutcome)
+			}
+			if got.TotalStake != tt.wantStake {
+				t.Errorf("TotalStake = %s, want %s", got.TotalStake, tt.wantStake)
+			}
+			if got.Returns != tt.wantReturns {
+				t.Errorf("Returns = %s, want %s", got.Returns, tt.wantReturns)
+			}
+			if got.Profit != tt.wantProfit {
+				t.Errorf("Profit = %s, want %s", got.Profit, tt.wantProfit)
+			}
+		})
+	}
+}
+
+func TestSettleSystemLegCount(t *testing.T) {
+	system, _ := LookupSystem("yankee")
+	legs := []models.EvaluationResult{leg("2.00", models.OutcomeWon), leg("2.00", models.OutcomeWon)}
+	if _, err := SettleSystem(system, legs, rat(t, "1")); !errors.Is(err, models.ErrInvalidBet) {
+		t.Errorf("SettleSystem with 2 legs for a Yankee: err = %v, want %v", err, models.ErrInvalidBet)
+	}
+}
@@ -0,0 +1,97 @@
+// Package sports holds the registry of sports the simulator can price and
+// settle. Each sport package registers itself from an init function.
+package sports
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"bet365-fiber-sim/models"
+)
+
+// Kind identifies which feed an event belongs to.
+type Kind string
+
+const (
+	Prematch Kind = "prematch"
+	Result   Kind = "result"
+)
+
+// Sport is implemented by every sport the simulator supports.
+type Sport interface {
+	// Name is the value clients pass as sport_type.
+	Name() string
+	// LoadPrematch replaces the prematch data with the decoded file content.
+	LoadPrematch(raw []byte) error
+	// LoadResults replaces the result data with the decoded file content.
+	LoadResults(raw []byte) error
+	// AvailableSelections lists the priced markets for an event.
+	AvailableSelections(eventID, fi string) ([]models.AvailableSelection, error)
+	// BuildSelection prices a request against the prematch data.
+	BuildSelection(req models.BetEvaluationRequest) (models.BetSelection, error)
+	// Evaluate settles a selection against the result data.
+	Evaluate(selection models.BetSelection) (models.EvaluationResult, error)
+	// StoreEvent decodes a bet365-shaped body holding a single event and
+	// stores it under id, overlaying any file event with the same ID.
+	StoreEvent(kind Kind, id string, body []byte) (any, error)
+	// StoredEvent returns the uploaded event stored under id.
+	StoredEvent(kind Kind, id string) (any, bool)
+	// DeleteStoredEvent removes an uploaded event and reports whether there
+	// was one.
+	DeleteStoredEvent(kind Kind, id string) bool
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Sport)
+)
+
+// Register makes a sport available by its name. It panics if the name is
+// already taken, as that can only be a programming error.
+func Register(sport Sport) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	name := sport.Name()
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("sports: Register called twice for %q", name))
+	}
+	registry[name] = sport
+}
+
+// Get returns the sport registered under name.
+func Get(name string) (Sport, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	sport, ok := registry[name]
+	return sport, ok
+}
+
+// Names returns the sorted names of all registered sports.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// All returns every registered sport, sorted by name.
+func All() []Sport {
+	names := Names()
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	all := make([]Sport, 0, len(names))
+	for _, name := range names {
+		all = append(all, registry[name])
+	}
+	return all
+}
@@ -0,0 +1,54 @@
+package sports
+
+import (
+	"fmt"
+
+	"bet365-fiber-sim/eventstore"
+	"bet365-fiber-sim/models"
+)
+
+// Uploads implements the upload methods of Sport over the prematch and
+// result feeds of a sport. Sports embed it.
+type Uploads[P, R any] struct {
+	Prematch eventstore.Feed[P]
+	Result   eventstore.Feed[R]
+}
+
+func (u Uploads[P, R]) StoreEvent(kind Kind, id string, body []byte) (any, error) {
+	var (
+		event any
+		err   error
+	)
+	switch kind {
+	case Prematch:
+		event, err = u.Prematch.Upload(id, body)
+	case Result:
+		event, err = u.Result.Upload(id, body)
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", models.ErrInvalidEvent, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (u Uploads[P, R]) StoredEvent(kind Kind, id string) (any, bool) {
+	switch kind {
+	case Prematch:
+		return u.Prematch.Store.Get(id)
+	case Result:
+		return u.Result.Store.Get(id)
+	}
+	return nil, false
+}
+
+func (u Uploads[P, R]) DeleteStoredEvent(kind Kind, id string) bool {
+	switch kind {
+	case Prematch:
+		return u.Prematch.Store.Delete(id)
+	case Result:
+		return u.Result.Store.Delete(id)
+	}
+	return false
+}
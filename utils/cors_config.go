@@ -1,13 +1,15 @@
 package utils
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
-func ConfigCORS(app *fiber.App) {
+func ConfigCORS(app *fiber.App, origins []string) {
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*", // Add your frontend URLs
+		AllowOrigins: strings.Join(origins, ","),
 		AllowMethods: "GET,POST,HEAD,PUT,DELETE,PATCH",
 		AllowHeaders: "*",
 	}))
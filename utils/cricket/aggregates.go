@@ -0,0 +1,145 @@
+package cricket_utils
+
+import (
+	"bet365-fiber-sim/models"
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"fmt"
+	"strings"
+)
+
+// Markets settled from the team aggregates, by display name.
+const (
+	matchTotalsMarket         = "Match Totals"
+	mostSixesMarket           = "Most Match Sixes"
+	mostFoursMarket           = "Most Match Fours"
+	mostRunOutsMarket         = "Most Run Outs (Fielding)"
+	wicketsCaughtMarket       = "Number of Wickets Caught in Match"
+	wicketsCaughtTeamMarket   = "Number of Wickets Caught in Match - Team"
+	fiftyScoredMarket         = "A Fifty to be scored"
+	hundredScoredMarket       = "A Hundred to be Scored in the Match"
+	sixBoundariesInOverMarket = "Six Boundaries in an Over - Match"
+)
+
+// MatchAggregates returns the aggregates of the home and away teams. It
+// reports false unless the result has both.
+func MatchAggregates(aggregates []cricket_models.TeamAggregates) (home, away cricket_models.TeamAggregates, ok bool) {
+	var haveHome, haveAway bool
+	for _, a := range aggregates {
+		switch a.Team {
+		case "1":
+			home, haveHome = a, true
+		case "2":
+			away, haveAway = a, true
+		}
+	}
+	return home, away, haveHome && haveAway
+}
+
+// EvaluateCricketAggregateMarket settles Match Totals and the match specials:
+// the Most Match Sixes, Fours and Run Outs (Fielding) 3-way markets ("1",
+// "Tie" or "2"), Number of Wickets Caught in Match (team and match), A Fifty
+// and A Hundred to be scored, and Six Boundaries in an Over. On the 3-way markets level
+// counts settle Tie as the winner and both teams as losers. The markets are
+// void when the result has no aggregates.
+func EvaluateCricketAggregateMarket(selection models.BetSelection, aggregates []cricket_models.TeamAggregates) models.EvaluationResult {
+	home, away, ok := MatchAggregates(aggregates)
+	if !ok {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "no match aggregates",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("%s needs the match aggregates of both teams, which the result does not have", selection.Market),
+		}
+	}
+
+	switch selection.Market {
+	case matchTotalsMarket:
+		return evaluateMatchTotals(selection, home, away)
+	case mostSixesMarket:
+		return evaluateMostOf(selection, home.Sixes, away.Sixes, "sixes")
+	case mostFoursMarket:
+		return evaluateMostOf(selection, home.Fours, away.Fours, "fours")
+	case mostRunOutsMarket:
+		return evaluateMostOf(selection, home.RunOuts, away.RunOuts, "run outs")
+	case wicketsCaughtMarket:
+		return evaluateCountOverUnder(selection, selection.Selection, selection.Handicap,
+			home.Catches+away.Catches, "wickets caught", "the match")
+	case wicketsCaughtTeamMarket:
+		var team cricket_models.TeamAggregates
+		switch selection.Selection {
+		case "1":
+			team = home
+		case "2":
+			team = away
+		default:
+			return models.EvaluationResult{
+				Selection:    selection,
+				ActualResult: "invalid team",
+				Outcome:      "void",
+				Description:  fmt.Sprintf("%s needs the team (1 or 2) as the selection, got '%s'", selection.Market, selection.Selection),
+			}
+		}
+		return evaluateCountOverUnder(selection, selection.Header, selection.Handicap,
+			team.Catches, "wickets caught", fmt.Sprintf("team %s's fielding", team.Team))
+	case fiftyScoredMarket:
+		fifties := home.Fifties + home.Hundreds + away.Fifties + away.Hundreds
+		return yesNoResult(selection, fifties > 0, fmt.Sprintf("%d scores of 50+", fifties))
+	case hundredScoredMarket:
+		hundreds := home.Hundreds + away.Hundreds
+		return yesNoResult(selection, hundreds > 0, fmt.Sprintf("%d hundreds", hundreds))
+	case sixBoundariesInOverMarket:
+		most := max(home.MaxOverBoundaries, away.MaxOverBoundaries)
+		return yesNoResult(selection, most >= 6, fmt.Sprintf("most boundaries in an over: %d", most))
+	}
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: "unknown market",
+		Outcome:      "void",
+		Description:  "Unknown market type",
+	}
+}
+
+// evaluateMatchTotals settles "Match Totals": the name is Fours, Sixes or
+// Boundaries (fours and sixes together), the header Over or Under and the
+// handicap the line. Both teams' boundaries count.
+func evaluateMatchTotals(selection models.BetSelection, home, away cricket_models.TeamAggregates) models.EvaluationResult {
+	var count int
+	switch selection.Selection {
+	case "Fours":
+		count = home.Fours + away.Fours
+	case "Sixes":
+		count = home.Sixes + away.Sixes
+	case "Boundaries":
+		count = home.Fours + home.Sixes + away.Fours + away.Sixes
+	default:
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "invalid total",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("Invalid total '%s' (must be Fours, Sixes or Boundaries)", selection.Selection),
+		}
+	}
+	return evaluateCountOverUnder(selection, selection.Header, selection.Handicap,
+		count, strings.ToLower(selection.Selection), "the match")
+}
+
+// evaluateMostOf settles a 1/Tie/2 selection on which team had more of what.
+func evaluateMostOf(selection models.BetSelection, home, away int, what string) models.EvaluationResult {
+	actual := "Tie"
+	switch {
+	case home > away:
+		actual = "1"
+	case away > home:
+		actual = "2"
+	}
+	return selectionResult(selection, actual, fmt.Sprintf("%d-%d %s (%s)", home, away, what, actual))
+}
+
+// yesNoResult settles a Yes/No selection.
+func yesNoResult(selection models.BetSelection, happened bool, detail string) models.EvaluationResult {
+	actual := "No"
+	if happened {
+		actual = "Yes"
+	}
+	return selectionResult(selection, actual, fmt.Sprintf("%s (%s)", detail, actual))
+}
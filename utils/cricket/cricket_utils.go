@@ -1,30 +1,55 @@
 package cricket_utils
 
 import (
+	"bet365-fiber-sim/eventstore"
 	"bet365-fiber-sim/models"
 	cricket_models "bet365-fiber-sim/models/cricket"
+	"bet365-fiber-sim/pricing"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"strconv"
-	"strings"
+	"slices"
+	"sync/atomic"
 )
 
-var PrematchData cricket_models.PrematchResponse
-var ResultData cricket_models.ResultResponse
+// The loaded data is swapped atomically on reload, so a request always sees
+// a complete snapshot and a failed reload leaves the previous one in place.
+var (
+	prematchData atomic.Pointer[cricket_models.PrematchResponse]
+	resultData   atomic.Pointer[cricket_models.ResultResponse]
+)
 
-func InitCricketHandlers() {
-	var err error
-	PrematchData, err = ReadCricketPrematchData("data/cricket_prematch.json")
-	if err != nil {
-		panic(fmt.Sprintf("Failed to load prematch data: %v", err))
+// Events uploaded through the admin API, overlaid on the file data.
+var (
+	uploadedPrematch eventstore.Store[cricket_models.PrematchEvent]
+	uploadedResults  eventstore.Store[cricket_models.ResultEvent]
+)
+
+// PrematchData returns the currently loaded prematch data, including uploaded
+// events.
+func PrematchData() cricket_models.PrematchResponse {
+	var data cricket_models.PrematchResponse
+	if loaded := prematchData.Load(); loaded != nil {
+		data = *loaded
 	}
+	data.Results = uploadedPrematch.Overlay(data.Results, func(e cricket_models.PrematchEvent) string {
+		return e.EventID
+	})
+	return data
+}
 
-	ResultData, err = ReadCricketResultData("data/cricket_result.json")
-	if err != nil {
-		panic(fmt.Sprintf("Failed to load result data: %v", err))
+// ResultData returns the currently loaded result data, including uploaded
+// events.
+func ResultData() cricket_models.ResultResponse {
+	var data cricket_models.ResultResponse
+	if loaded := resultData.Load(); loaded != nil {
+		data = *loaded
 	}
+	data.Results = uploadedResults.Overlay(data.Results, func(e cricket_models.ResultEvent) string {
+		return e.ID
+	})
+	return data
 }
 
 // ReadPrematchData reads and parses prematch JSON data
@@ -42,10 +67,16 @@ func ReadCricketPrematchData(filename string) (cricket_models.PrematchResponse,
 		return data, fmt.Errorf("failed to read file: %v", err)
 	}
 
-	if err := json.Unmarshal(bytes, &data); err != nil {
+	return DecodeCricketPrematchData(bytes)
+}
+
+// DecodeCricketPrematchData parses a bet365 prematch response. It is shared
+// by the file loader and the admin upload API.
+func DecodeCricketPrematchData(raw []byte) (cricket_models.PrematchResponse, error) {
+	var data cricket_models.PrematchResponse
+	if err := json.Unmarshal(raw, &data); err != nil {
 		return data, fmt.Errorf("failed to parse JSON: %v", err)
 	}
-
 	return data, nil
 }
 
@@ -63,92 +94,169 @@ func ReadCricketResultData(filename string) (cricket_models.ResultResponse, erro
 		return data, fmt.Errorf("failed to read file: %v", err)
 	}
 
-	if err := json.Unmarshal(bytes, &data); err != nil {
+	return DecodeCricketResultData(bytes)
+}
+
+// DecodeCricketResultData parses a bet365 result response. It is shared
+// by the file loader and the admin upload API.
+func DecodeCricketResultData(raw []byte) (cricket_models.ResultResponse, error) {
+	var data cricket_models.ResultResponse
+	if err := json.Unmarshal(raw, &data); err != nil {
 		return data, fmt.Errorf("failed to parse JSON: %v", err)
 	}
-
 	return data, nil
 }
 
-// CreateSelectionFromPrematch creates a bet selection from prematch data
-func CreateCricketSelectionFromPrematch(data cricket_models.PrematchResponse, market, selection string, handicap ...string) models.BetSelection {
-	for _, result := range data.Results {
-		for _, m := range result.Markets {
-			if m.Name == market && (m.Header == selection || m.Name == selection) && (len(handicap) == 0 || m.Handicap == handicap[0]) {
-				odds := m.Odds
-				return models.BetSelection{
-					Market:    market,
-					Selection: selection,
-					Odds:      odds,
-					Handicap:  m.Handicap,
-				}
+// FindCricketPrematchEvent returns the prematch event addressed by eventID
+// and/or fi. When neither is given the only loaded event is used.
+func FindCricketPrematchEvent(data cricket_models.PrematchResponse, eventID, fi string) (*cricket_models.PrematchEvent, error) {
+	return models.FindEvent(data.Results, eventID, fi,
+		func(e *cricket_models.PrematchEvent) string { return e.EventID },
+		func(e *cricket_models.PrematchEvent) string { return e.FI })
+}
+
+// FindCricketResultEvent returns the result for the event addressed by
+// eventID (result id) and/or fi (result bet365_id).
+func FindCricketResultEvent(data cricket_models.ResultResponse, eventID, fi string) (*cricket_models.ResultEvent, error) {
+	return models.FindEvent(data.Results, eventID, fi,
+		func(e *cricket_models.ResultEvent) string { return e.ID },
+		func(e *cricket_models.ResultEvent) string { return e.Bet365ID })
+}
+
+// CreateCricketSelectionFromRequest prices the requested selection from the
+// prematch data of the event the request points at.
+func CreateCricketSelectionFromRequest(req cricket_models.BetEvaluationRequest) (models.BetSelection, error) {
+	event, err := FindCricketPrematchEvent(PrematchData(), req.EventID, req.FI)
+	if err != nil {
+		return models.BetSelection{}, err
+	}
+
+	var selection models.BetSelection
+	if req.Market == "Double Chance" {
+		option, err := pricing.FindSelection(GetCricketDoubleChanceSelections(event), req.Selection)
+		if err != nil {
+			return selection, err
+		}
+		selection = models.BetSelection{
+			Market:    req.Market,
+			Selection: req.Selection,
+			Odds:      option.Odds,
+		}
+	} else {
+		if key, m, ok := FindCricketMarket(event, req.Market); ok {
+			if !slices.Contains(SupportedMarkets, key) {
+				return selection, fmt.Errorf("%w: %s is not settled by the simulator", models.ErrMarketUnavailable, req.Market)
+			}
+			if len(MarketOdds(key, m)) == 0 {
+				return selection, fmt.Errorf("%w: %s has no usable prices in the feed", models.ErrMarketUnavailable, req.Market)
 			}
 		}
+		selection = CreateCricketSelectionFromPrematch(event, req.Market, req.Selection, req.Header, req.Handicap)
+	}
+	if selection.Market == "" {
+		return selection, models.ErrInvalidSelection
 	}
-	return models.BetSelection{}
+
+	selection.EventID = event.EventID
+	selection.FI = event.FI
+	return selection, nil
 }
 
-// EvaluateSelection evaluates a bet selection against the result data
-func EvaluateCricketSelection(selection models.BetSelection, resultData cricket_models.ResultResponse) models.EvaluationResult {
-	if len(resultData.Results) == 0 {
-		return models.EvaluationResult{
-			Selection:    selection,
-			ActualResult: "no result data",
-			Outcome:      "void",
-			Description:  "No result data available",
-		}
+// CreateSelectionFromPrematch creates a bet selection from prematch data.
+// market may be the feed key or the display name; header and handicap are
+// optional filters.
+func CreateCricketSelectionFromPrematch(event *cricket_models.PrematchEvent, market, selection, header, handicap string) models.BetSelection {
+	key, m, ok := FindCricketMarket(event, market)
+	if !ok {
+		return models.BetSelection{}
+	}
+
+	odd, ok := FindCricketOdd(key, m, selection, header, handicap)
+	if !ok {
+		return models.BetSelection{}
 	}
 
-	result := resultData.Results[0]
-	scores := strings.Split(result.SS, "-")
-	if len(scores) != 2 {
+	return models.BetSelection{
+		Market:    m.Name,
+		Selection: odd.Name,
+		Header:    odd.Header,
+		Odds:      odd.Odds,
+		Handicap:  odd.Handicap,
+	}
+}
+
+// EvaluateSelection evaluates a bet selection against the result of the
+// event it was priced on. It is an error if that event has no result.
+func EvaluateCricketSelection(selection models.BetSelection, resultData cricket_models.ResultResponse) (models.EvaluationResult, error) {
+	result, err := FindCricketResultEvent(resultData, selection.EventID, selection.FI)
+	if err != nil {
+		return models.EvaluationResult{}, err
+	}
+
+	switch selection.Market {
+	case "To Win the Match", "Double Chance", "To go to Super Over?":
+		return evaluateCricketScoreMarket(selection, result), nil
+	case "Match Handicap":
+		return EvaluateCricketMatchHandicap(selection, result), nil
+	case teamTopBatterMarket, teamTopBowlerMarket, topMatchBatterMarket, topMatchBowlerMarket, playerOfTheMatchMarket:
+		return EvaluateCricketPlayerMarket(selection, result.Scorecard), nil
+	case firstOverRunsMarket, firstOverOddEvenMarket, firstBallDotMarket, runsOffDeliveryMarket, firstScoringShotMarket, firstOverSixByTeamMarket:
+		return EvaluateCricketDeliveryMarket(selection, result.Deliveries), nil
+	case runsInFirstOversMarket:
+		return EvaluateCricketRunsInFirstOvers(selection, result.Innings), nil
+	case highestFirstSixOversMarket:
+		return EvaluateCricketHighestFirstSixOvers(selection, result.Innings), nil
+	case firstWicketMethodMarket, firstWicketMethodTwoWayMarket, firstWicketMethodTeamMarket, firstWicketMethodTeamTwoWayMarket,
+		runsAtFirstWicketMarket, runsAtFirstWicketThreeWayMarket, runsAtFirstWicketTeamMarket:
+		return EvaluateCricketFirstWicket(selection, result.Innings), nil
+	case matchTotalsMarket, mostSixesMarket, mostFoursMarket, mostRunOutsMarket, wicketsCaughtMarket, wicketsCaughtTeamMarket,
+		fiftyScoredMarket, hundredScoredMarket, sixBoundariesInOverMarket:
+		return EvaluateCricketAggregateMarket(selection, result.Aggregates), nil
+	default:
 		return models.EvaluationResult{
 			Selection:    selection,
-			ActualResult: "invalid score format",
+			ActualResult: "unknown market",
 			Outcome:      "void",
-			Description:  "Score format is invalid",
-		}
+			Description:  "Unknown market type",
+		}, nil
 	}
+}
 
-	homeRuns, err1 := strconv.Atoi(scores[0])
-	awayRuns, err2 := strconv.Atoi(scores[1])
-	if err1 != nil || err2 != nil {
+// evaluateCricketScoreMarket settles the markets decided by the runs in the
+// "ss" score: To Win the Match, Double Chance and To go to Super Over?. They
+// are void when the score cannot be parsed.
+func evaluateCricketScoreMarket(selection models.BetSelection, result *cricket_models.ResultEvent) models.EvaluationResult {
+	score, err := ParseCricketScore(result.SS)
+	if err != nil {
 		return models.EvaluationResult{
 			Selection:    selection,
-			ActualResult: "invalid score values",
+			ActualResult: "invalid score format",
 			Outcome:      "void",
-			Description:  "Could not parse score values",
+			Description:  fmt.Sprintf("Could not parse score: %v", err),
 		}
 	}
+	homeRuns, awayRuns := score.HomeRuns(), score.AwayRuns()
 
 	switch selection.Market {
-	case "Match Winner":
-		return EvaluateCricketMatchWinner(selection, homeRuns, awayRuns)
-	case "Total Runs":
-		return EvaluateCricketTotalRuns(selection, homeRuns, awayRuns)
-	case "Correct Score":
-		return EvaluateCricketCorrectScore(selection, homeRuns, awayRuns)
+	case "To Win the Match":
+		return EvaluateCricketMatchWinner(selection, homeRuns, awayRuns, result.SuperOver)
 	case "Double Chance":
 		return EvaluateCricketDoubleChance(selection, homeRuns, awayRuns)
 	default:
-		return models.EvaluationResult{
-			Selection:    selection,
-			ActualResult: "unknown market",
-			Outcome:      "void",
-			Description:  "Unknown market type",
-		}
+		return EvaluateCricketSuperOver(selection, homeRuns, awayRuns, result.SuperOver)
 	}
 }
 
-// EvaluateMatchWinner evaluates a Match Winner bet
-func EvaluateCricketMatchWinner(selection models.BetSelection, homeRuns, awayRuns int) models.EvaluationResult {
+// EvaluateMatchWinner evaluates a Match Winner bet. To Win the Match is
+// two-way, so level runs are settled by MatchTieRule.
+func EvaluateCricketMatchWinner(selection models.BetSelection, homeRuns, awayRuns int, superOver *cricket_models.SuperOver) models.EvaluationResult {
 	var winner string
 	if homeRuns > awayRuns {
 		winner = "1"
 	} else if awayRuns > homeRuns {
 		winner = "2"
 	} else {
-		winner = "X" // Tie
+		return evaluateTiedMatch(selection, homeRuns, awayRuns, superOver)
 	}
 
 	outcome := "lost"
@@ -164,77 +272,6 @@ func EvaluateCricketMatchWinner(selection models.BetSelection, homeRuns, awayRun
 	}
 }
 
-// EvaluateTotalRuns evaluates a Total Runs bet
-func EvaluateCricketTotalRuns(selection models.BetSelection, homeRuns, awayRuns int) models.EvaluationResult {
-	parts := strings.Fields(selection.Handicap)
-	if len(parts) < 2 {
-		return models.EvaluationResult{
-			Selection:    selection,
-			ActualResult: "invalid total format",
-			Outcome:      "void",
-			Description:  fmt.Sprintf("Invalid total format: '%s'", selection.Handicap),
-		}
-	}
-
-	target, err := strconv.ParseFloat(parts[1], 64)
-	if err != nil {
-		return models.EvaluationResult{
-			Selection:    selection,
-			ActualResult: "invalid total value",
-			Outcome:      "void",
-			Description:  fmt.Sprintf("Failed to parse total value '%s': %v", parts[1], err),
-		}
-	}
-
-	outcome := "lost"
-	total := float64(homeRuns + awayRuns)
-
-	switch parts[0] {
-	case "O":
-		if total > target {
-			outcome = "won"
-		} else if total == target {
-			outcome = "push"
-		}
-	case "U":
-		if total < target {
-			outcome = "won"
-		} else if total == target {
-			outcome = "push"
-		}
-	default:
-		return models.EvaluationResult{
-			Selection:    selection,
-			ActualResult: "invalid total type",
-			Outcome:      "void",
-			Description:  fmt.Sprintf("Invalid total type '%s' (must be O/U)", parts[0]),
-		}
-	}
-
-	return models.EvaluationResult{
-		Selection:    selection,
-		ActualResult: fmt.Sprintf("%d runs (Home: %d, Away: %d)", homeRuns+awayRuns, homeRuns, awayRuns),
-		Outcome:      outcome,
-		Description:  fmt.Sprintf("Selected %s %s (target %.1f), actual was %.0f", parts[0], selection.Handicap, target, total),
-	}
-}
-
-// EvaluateCorrectScore evaluates a Correct Score bet
-func EvaluateCricketCorrectScore(selection models.BetSelection, homeRuns, awayRuns int) models.EvaluationResult {
-	actualScore := fmt.Sprintf("%d-%d", homeRuns, awayRuns)
-	outcome := "lost"
-	if selection.ScoreLine == actualScore {
-		outcome = "won"
-	}
-
-	return models.EvaluationResult{
-		Selection:    selection,
-		ActualResult: actualScore,
-		Outcome:      outcome,
-		Description:  fmt.Sprintf("Selected %s, actual was %s", selection.ScoreLine, actualScore),
-	}
-}
-
 // EvaluateDoubleChance evaluates a Double Chance bet
 func EvaluateCricketDoubleChance(selection models.BetSelection, homeRuns, awayRuns int) models.EvaluationResult {
 	var actualOutcome string
@@ -270,80 +307,18 @@ func EvaluateCricketDoubleChance(selection models.BetSelection, homeRuns, awayRu
 	}
 }
 
-// Get1X2Selections returns available Match Winner selections
-func GetCricket1X2Selections() models.AvailableSelection {
-	selections := []struct {
-		Name     string `json:"name"`
-		Odds     string `json:"odds"`
-		Handicap string `json:"handicap,omitempty"`
-	}{}
-
-	for _, result := range PrematchData.Results {
-		for _, market := range result.Markets {
-			if market.Name == "Match Winner" {
-				odds := market.Odds
-				selections = append(selections, struct {
-					Name     string `json:"name"`
-					Odds     string `json:"odds"`
-					Handicap string `json:"handicap,omitempty"`
-				}{
-					Name:     market.Header,
-					Odds:     odds,
-					Handicap: market.Handicap,
-				})
-			}
-		}
-	}
-
-	return models.AvailableSelection{
-		Market:     "Match Winner (1X2)",
-		Selections: selections,
-	}
-}
-
-// GetTotalRunsSelections returns available Total Runs selections
-func GetCricketTotalRunsSelections() models.AvailableSelection {
-	selections := []struct {
-		Name     string `json:"name"`
-		Odds     string `json:"odds"`
-		Handicap string `json:"handicap,omitempty"`
-	}{}
-
-	for _, result := range PrematchData.Results {
-		for _, market := range result.Markets {
-			if market.Name == "Total Runs" {
-				odds := market.Odds
-				selections = append(selections, struct {
-					Name     string `json:"name"`
-					Odds     string `json:"odds"`
-					Handicap string `json:"handicap,omitempty"`
-				}{
-					Name:     market.Header,
-					Odds:     odds,
-					Handicap: market.Handicap,
-				})
-			}
-		}
-	}
-
-	return models.AvailableSelection{
-		Market:     "Total Runs",
-		Selections: selections,
-	}
+// Get1X2Selections returns available To Win the Match selections
+func GetCricket1X2Selections(event *cricket_models.PrematchEvent) models.AvailableSelection {
+	return GetCricketMarketSelections(event, "to_win_the_match")
 }
 
-// GetDoubleChanceSelections returns available Double Chance selections
-func GetCricketDoubleChanceSelections() models.AvailableSelection {
-	return models.AvailableSelection{
-		Market: "Double Chance",
-		Selections: []struct {
-			Name     string `json:"name"`
-			Odds     string `json:"odds"`
-			Handicap string `json:"handicap,omitempty"`
-		}{
-			{Name: "1X", Odds: "1.10"},
-			{Name: "12", Odds: "1.05"},
-			{Name: "X2", Odds: "1.20"},
-		},
+// GetCricketDoubleChanceSelections returns the Double Chance market, priced
+// from the feed when it carries one and otherwise derived from To Win the
+// Match. It is unavailable when the match has no draw price.
+func GetCricketDoubleChanceSelections(event *cricket_models.PrematchEvent) models.AvailableSelection {
+	var feed []models.SelectionOption
+	if key, _, ok := FindCricketMarket(event, "double_chance"); ok {
+		feed = GetCricketMarketSelections(event, key).Selections
 	}
+	return pricing.DoubleChanceMarket(feed, GetCricket1X2Selections(event).Selections)
 }
@@ -0,0 +1,298 @@
+package cricket_utils
+
+import (
+	"bet365-fiber-sim/models"
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Markets settled from the ball-by-ball record, by display name.
+const (
+	firstOverRunsMarket      = "1st Over Total Runs"
+	firstOverOddEvenMarket   = "1st Over - Total Runs Odd/Even"
+	firstBallDotMarket       = "First Match Ball to be A Dot"
+	runsOffDeliveryMarket    = "Match - Runs off x Delivery"
+	firstScoringShotMarket   = "1st Scoring Shot of the Match"
+	firstOverSixByTeamMarket = "1st Over of Match - 6 Scored - Team"
+)
+
+const ballsPerOver = 6
+
+var deliveryRow = regexp.MustCompile(`^(\d+)(?:st|nd|rd|th) Ball$`)
+
+// OverDeliveries returns the deliveries of one over of an innings and
+// whether the over is complete (all six legal balls are recorded).
+func OverDeliveries(deliveries []cricket_models.Delivery, innings, over int) ([]cricket_models.Delivery, bool) {
+	var balls []cricket_models.Delivery
+	legal := 0
+	for _, d := range deliveries {
+		if d.Innings == innings && d.Over == over {
+			balls = append(balls, d)
+			if d.Legal() {
+				legal++
+			}
+		}
+	}
+	return balls, legal == ballsPerOver
+}
+
+// BallDeliveries returns the deliveries of one ball of an over: any wides
+// and no balls bowled before it and the legal ball itself, in the order
+// bowled. It reports false when the legal ball is not recorded.
+func BallDeliveries(deliveries []cricket_models.Delivery, innings, over, ball int) ([]cricket_models.Delivery, bool) {
+	var balls []cricket_models.Delivery
+	legal := false
+	for _, d := range matchOrder(deliveries) {
+		if d.Innings == innings && d.Over == over && d.Ball == ball {
+			balls = append(balls, d)
+			legal = legal || d.Legal()
+		}
+	}
+	return balls, legal
+}
+
+// FirstScoringShot returns the first delivery of the match with runs off
+// the bat. It reports false when there is none or the record has a gap
+// before it.
+func FirstScoringShot(deliveries []cricket_models.Delivery) (cricket_models.Delivery, bool) {
+	over, ball := 1, 1
+	for _, d := range matchOrder(deliveries) {
+		if d.Innings != 1 || d.Over != over || d.Ball != ball {
+			return cricket_models.Delivery{}, false
+		}
+		if d.Runs > 0 {
+			return d, true
+		}
+		if d.Legal() {
+			if ball++; ball > ballsPerOver {
+				over, ball = over+1, 1
+			}
+		}
+	}
+	return cricket_models.Delivery{}, false
+}
+
+// matchOrder returns the deliveries sorted by innings, over and ball, with
+// the wides and no balls of a ball before its legal delivery.
+func matchOrder(deliveries []cricket_models.Delivery) []cricket_models.Delivery {
+	sorted := slices.Clone(deliveries)
+	slices.SortStableFunc(sorted, func(a, b cricket_models.Delivery) int {
+		return cmp.Or(
+			cmp.Compare(a.Innings, b.Innings),
+			cmp.Compare(a.Over, b.Over),
+			cmp.Compare(a.Ball, b.Ball),
+			compareLegal(a, b),
+		)
+	})
+	return sorted
+}
+
+func compareLegal(a, b cricket_models.Delivery) int {
+	switch {
+	case a.Legal() == b.Legal():
+		return 0
+	case a.Legal():
+		return 1
+	}
+	return -1
+}
+
+// EvaluateCricketDeliveryMarket settles the markets that need the
+// ball-by-ball record. It is void when the deliveries the market needs are
+// missing.
+func EvaluateCricketDeliveryMarket(selection models.BetSelection, deliveries []cricket_models.Delivery) models.EvaluationResult {
+	switch selection.Market {
+	case firstOverRunsMarket:
+		balls, ok := OverDeliveries(deliveries, 1, 1)
+		if !ok {
+			return deliveriesVoid(selection, "the first over of the match")
+		}
+		return evaluateDeliveryOverUnder(selection, selection.Header, selection.Selection, sumRuns(balls), "the 1st over")
+	case firstOverOddEvenMarket:
+		balls, ok := OverDeliveries(deliveries, 1, 1)
+		if !ok {
+			return deliveriesVoid(selection, "the first over of the match")
+		}
+		runs := sumRuns(balls)
+		actual := "Even"
+		if runs%2 != 0 {
+			actual = "Odd"
+		}
+		return selectionResult(selection, actual, fmt.Sprintf("%d runs (%s)", runs, actual))
+	case firstBallDotMarket:
+		balls, ok := BallDeliveries(deliveries, 1, 1, 1)
+		if !ok {
+			return deliveriesVoid(selection, "the first ball of the match")
+		}
+		first := balls[0]
+		actual := "No"
+		if first.Total() == 0 {
+			actual = "Yes"
+		}
+		return selectionResult(selection, actual, fmt.Sprintf("%d runs (%s)", first.Total(), actual))
+	case runsOffDeliveryMarket:
+		return evaluateRunsOffDelivery(selection, deliveries)
+	case firstScoringShotMarket:
+		d, ok := FirstScoringShot(deliveries)
+		if !ok {
+			return deliveriesVoid(selection, "the first scoring shot of the match")
+		}
+		actual := scoringShot(d.Runs)
+		return selectionResult(selection, actual, fmt.Sprintf("%s by %s", actual, d.Batter))
+	case firstOverSixByTeamMarket:
+		return evaluateFirstOverSix(selection, deliveries)
+	}
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: "unknown market",
+		Outcome:      "void",
+		Description:  "Unknown market type",
+	}
+}
+
+// evaluateRunsOffDelivery settles "Match - Runs off x Delivery": the name is
+// the ball of the first over ("1st Ball"), the header Over or Under and the
+// handicap the line. Extras count, including those of a wide or no ball
+// bowled before the legal ball.
+func evaluateRunsOffDelivery(selection models.BetSelection, deliveries []cricket_models.Delivery) models.EvaluationResult {
+	m := deliveryRow.FindStringSubmatch(selection.Selection)
+	if m == nil {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "invalid delivery",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("Invalid delivery '%s'", selection.Selection),
+		}
+	}
+	ball, _ := strconv.Atoi(m[1])
+
+	balls, ok := BallDeliveries(deliveries, 1, 1, ball)
+	if !ok {
+		return deliveriesVoid(selection, "the "+strings.ToLower(selection.Selection)+" of the match")
+	}
+	return evaluateDeliveryOverUnder(selection, selection.Header, selection.Handicap, sumRuns(balls),
+		"the "+strings.ToLower(selection.Selection))
+}
+
+// evaluateFirstOverSix settles "1st Over of Match - 6 Scored - Team": the
+// name is the team and the header Yes or No. A team's first over is the first
+// over of its first innings.
+func evaluateFirstOverSix(selection models.BetSelection, deliveries []cricket_models.Delivery) models.EvaluationResult {
+	// The team's first innings, whatever order the deliveries were recorded in.
+	innings := 0
+	for _, d := range matchOrder(deliveries) {
+		if d.Team == selection.Selection {
+			innings = d.Innings
+			break
+		}
+	}
+	balls, ok := OverDeliveries(deliveries, innings, 1)
+	if innings == 0 || !ok {
+		return deliveriesVoid(selection, fmt.Sprintf("the first over of team %s", selection.Selection))
+	}
+
+	actual := "No"
+	for _, d := range balls {
+		if d.Runs == 6 {
+			actual = "Yes"
+			break
+		}
+	}
+	outcome := "lost"
+	if selection.Header == actual {
+		outcome = "won"
+	}
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("%s (%s)", selection.Selection, actual),
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s for team %s, six in first over: %s", selection.Header, selection.Selection, actual),
+	}
+}
+
+func evaluateDeliveryOverUnder(selection models.BetSelection, side, lineText string, runs int, what string) models.EvaluationResult {
+	return evaluateCountOverUnder(selection, side, lineText, runs, "runs", what)
+}
+
+// evaluateCountOverUnder settles an Over/Under selection on a count, e.g.
+// the runs or wickets of what. Landing on a whole-number line is a push.
+func evaluateCountOverUnder(selection models.BetSelection, side, lineText string, count int, unit, what string) models.EvaluationResult {
+	line, err := strconv.ParseFloat(lineText, 64)
+	if err != nil || (side != "Over" && side != "Under") {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "invalid line",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("Invalid Over/Under line '%s %s'", side, lineText),
+		}
+	}
+
+	actual := float64(count)
+	outcome := "lost"
+	switch {
+	case actual == line:
+		outcome = "push"
+	case side == "Over" && actual > line, side == "Under" && actual < line:
+		outcome = "won"
+	}
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("%d %s", count, unit),
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s %s, %s produced %d %s", side, lineText, what, count, unit),
+	}
+}
+
+// selectionResult settles a selection that wins when it names the actual
+// outcome.
+func selectionResult(selection models.BetSelection, actual, actualResult string) models.EvaluationResult {
+	outcome := "lost"
+	if selection.Selection == actual {
+		outcome = "won"
+	}
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: actualResult,
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s, actual was %s", selection.Selection, actual),
+	}
+}
+
+func deliveriesVoid(selection models.BetSelection, missing string) models.EvaluationResult {
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: "deliveries missing",
+		Outcome:      "void",
+		Description:  fmt.Sprintf("The ball-by-ball record does not cover %s, bets are void", missing),
+	}
+}
+
+func sumRuns(deliveries []cricket_models.Delivery) int {
+	runs := 0
+	for _, d := range deliveries {
+		runs += d.Total()
+	}
+	return runs
+}
+
+// scoringShot names the runs off the bat as the feed's selections do.
+func scoringShot(runs int) string {
+	switch runs {
+	case 1:
+		return "Single"
+	case 2:
+		return "Two"
+	case 3:
+		return "Three"
+	case 4:
+		return "Four"
+	case 6:
+		return "Six"
+	}
+	return "Other"
+}
@@ -0,0 +1,107 @@
+package cricket_utils
+
+import (
+	"bet365-fiber-sim/models"
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// handicapLine matches Match Handicap selections such as
+// "-4.5 wkts/-12.5 runs".
+var handicapLine = regexp.MustCompile(`^([+-]?\d+(?:\.\d+)?) wkts/([+-]?\d+(?:\.\d+)?) runs$`)
+
+// Margin is the result of a two-innings match. ByRuns is true when the team
+// batting first won (or the match was tied) and Margin is in runs; otherwise
+// the chasing team won and Margin is in wickets.
+type Margin struct {
+	Winner string // "1", "2" or "X" for a tie
+	Margin int
+	ByRuns bool
+}
+
+// MatchMargin works out the winner and winning margin from the innings
+// breakdown. It reports false unless the result has exactly two innings, one
+// per team.
+func MatchMargin(innings []cricket_models.Innings) (Margin, bool) {
+	if len(innings) != 2 || innings[0].Team == innings[1].Team {
+		return Margin{}, false
+	}
+	first, second := innings[0], innings[1]
+
+	switch {
+	case first.Runs > second.Runs:
+		return Margin{Winner: first.Team, Margin: first.Runs - second.Runs, ByRuns: true}, true
+	case second.Runs > first.Runs:
+		return Margin{Winner: second.Team, Margin: 10 - second.Wickets}, true
+	}
+	return Margin{Winner: "X", ByRuns: true}, true
+}
+
+// String describes the margin, e.g. "2 won by 100 runs".
+func (m Margin) String() string {
+	if m.Winner == "X" {
+		return "tie"
+	}
+	unit := "wickets"
+	if m.ByRuns {
+		unit = "runs"
+	}
+	return fmt.Sprintf("%s won by %d %s", m.Winner, m.Margin, unit)
+}
+
+// EvaluateCricketMatchHandicap settles a Match Handicap selection. The header
+// is the team ("1" or "2") and the name holds both lines, e.g. "-4.5
+// wkts/-12.5 runs". The runs line applies when the team batting first wins
+// and the wickets line otherwise; a tie is a zero-run margin. It is void
+// without an innings breakdown.
+func EvaluateCricketMatchHandicap(selection models.BetSelection, result *cricket_models.ResultEvent) models.EvaluationResult {
+	m := handicapLine.FindStringSubmatch(selection.Selection)
+	if m == nil || (selection.Header != "1" && selection.Header != "2") {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "invalid handicap format",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("Invalid Match Handicap selection '%s' (header '%s')", selection.Selection, selection.Header),
+		}
+	}
+
+	margin, ok := MatchMargin(result.Innings)
+	if !ok {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "no innings breakdown",
+			Outcome:      "void",
+			Description:  "Match Handicap needs the innings breakdown, which the result does not have",
+		}
+	}
+
+	lineText, unit := m[1], "wkts"
+	if margin.ByRuns {
+		lineText, unit = m[2], "runs"
+	}
+	line, _ := strconv.ParseFloat(lineText, 64)
+
+	// The margin from the selected team's point of view.
+	diff := float64(margin.Margin)
+	if margin.Winner != selection.Header {
+		diff = -diff
+	}
+	adjusted := diff + line
+
+	outcome := "lost"
+	if adjusted > 0 {
+		outcome = "won"
+	} else if adjusted == 0 {
+		outcome = "push"
+	}
+
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: margin.String(),
+		Outcome:      outcome,
+		Description: fmt.Sprintf("Selected %s %s %s, %s gives %g",
+			selection.Header, lineText, unit, margin, adjusted),
+	}
+}
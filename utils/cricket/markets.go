@@ -0,0 +1,219 @@
+package cricket_utils
+
+import (
+	"bet365-fiber-sim/models"
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"strings"
+)
+
+// SupportedMarkets lists, in display order, the feed keys of the markets the
+// simulator can settle. Only these are offered by /selections.
+var SupportedMarkets = []string{
+	"to_win_the_match",
+	"match_handicap",
+	"match_totals",
+	"to_go_to_super_over?",
+	"team_top_batter",
+	"team_top_bowler",
+	"top_match_batter",
+	"top_match_bowler",
+	"player_of_the_match",
+	"1st_over_total_runs",
+	"1st_over_total_runs_odd_even",
+	"first_match_ball_to_be_a_dot",
+	"match_runs_off_x_delivery",
+	"1st_scoring_shot_of_the_match",
+	"1st_over_of_match_6_scored_team",
+	"match_runs_in_1st_x_overs",
+	"team_to_make_highest_1st_6_overs_score",
+	"1st_wicket_method",
+	"match_1st_wicket_method_(2_way)",
+	"match_1st_wicket_method_team",
+	"match_1st_wicket_method_team_(2_way)",
+	"runs_at_fall_of_1st_wicket",
+	"match_runs_at_fall_of_first_wicket_(3_way)",
+	"match_runs_at_fall_of_first_wicket_team_(3_way)",
+	"most_match_sixes",
+	"most_match_fours",
+	"most_run_outs_(fielding)",
+	"number_of_wickets_caught_in_match",
+	"number_of_wickets_caught_in_match_team",
+	"a_fifty_to_be_scored",
+	"a_hundred_to_be_scored_in_the_match",
+	"six_boundaries_in_an_over_match",
+}
+
+// CricketMarkets flattens every market group of the event into one map keyed
+// by the feed slug. When a market appears in several groups the copy with
+// the most prices wins, so empty placeholders never shadow priced markets.
+func CricketMarkets(event *cricket_models.PrematchEvent) map[string]cricket_models.Market {
+	groups := []cricket_models.MarketGroup{
+		event.Main,
+		event.Match,
+		event.Player,
+		event.Team,
+		event.Innings1,
+		event.FirstOver,
+	}
+	groups = append(groups, event.Others...)
+
+	markets := make(map[string]cricket_models.Market)
+	for _, group := range groups {
+		for key, market := range group.Sp {
+			if existing, ok := markets[key]; ok && countPrices(existing) >= countPrices(market) {
+				continue
+			}
+			markets[key] = market
+		}
+	}
+	return markets
+}
+
+// FindCricketMarket looks a market up by feed key ("to_win_the_match") or by
+// display name ("To Win the Match"), ignoring case. It returns the key the
+// market is stored under.
+func FindCricketMarket(event *cricket_models.PrematchEvent, market string) (string, cricket_models.Market, bool) {
+	markets := CricketMarkets(event)
+	if m, ok := markets[market]; ok {
+		return market, m, true
+	}
+	for key, m := range markets {
+		if strings.EqualFold(key, market) || strings.EqualFold(m.Name, market) {
+			return key, m, true
+		}
+	}
+	return "", cricket_models.Market{}, false
+}
+
+// ResolveOdds returns the priced entries of a market with their labels filled
+// in.
+//
+// Grid markets are sent as a run of "PC<id>" label rows followed by the
+// prices. A price is matched to its row by ID when the IDs line up, and
+// otherwise by its position within the run (prices are sent column by
+// column, one per row). When a price has its own header (e.g. "Over") the
+// row header is moved to Name2; otherwise the row header is kept.
+func ResolveOdds(market cricket_models.Market) []cricket_models.Odd {
+	rowsByID := make(map[string]cricket_models.Odd)
+	var rows []cricket_models.Odd
+	var resolved []cricket_models.Odd
+	column := 0
+	inRows := false
+
+	for _, odd := range market.Odds {
+		if strings.HasPrefix(odd.ID, "PC") {
+			if !inRows {
+				rows = nil
+				column = 0
+				inRows = true
+			}
+			rows = append(rows, odd)
+			rowsByID[strings.TrimPrefix(odd.ID, "PC")] = odd
+			continue
+		}
+		inRows = false
+
+		if len(rows) == 0 {
+			if odd.Odds != "" {
+				resolved = append(resolved, odd)
+			}
+			continue
+		}
+
+		row, ok := rowsByID[odd.ID]
+		if !ok {
+			row = rows[column%len(rows)]
+		}
+		column++
+		if odd.Odds == "" {
+			continue
+		}
+
+		merged := odd
+		merged.Name = row.Name
+		if odd.Header == "" || odd.Header == "Odds" {
+			merged.Header = row.Header
+		} else if row.Header != "" {
+			merged.Name2 = row.Header
+		}
+		resolved = append(resolved, merged)
+	}
+
+	return resolved
+}
+
+// lineMarkets are the Over/Under markets whose prices mean nothing without
+// their line. The feed sometimes sends them unlabelled; such prices are
+// dropped, which leaves the market unavailable.
+var lineMarkets = map[string]bool{
+	"match_runs_off_x_delivery":         true,
+	"number_of_wickets_caught_in_match": true,
+}
+
+// MarketOdds resolves the prices of the market stored under key.
+func MarketOdds(key string, market cricket_models.Market) []cricket_models.Odd {
+	odds := ResolveOdds(market)
+	if !lineMarkets[key] {
+		return odds
+	}
+	lined := make([]cricket_models.Odd, 0, len(odds))
+	for _, odd := range odds {
+		if odd.Handicap != "" {
+			lined = append(lined, odd)
+		}
+	}
+	return lined
+}
+
+// GetCricketMarketSelections lists the priced selections of the market stored
+// under key.
+func GetCricketMarketSelections(event *cricket_models.PrematchEvent, key string) models.AvailableSelection {
+	selections := []models.SelectionOption{}
+
+	market, ok := CricketMarkets(event)[key]
+	if !ok {
+		return models.AvailableSelection{Market: key, Selections: selections}
+	}
+
+	for _, odd := range MarketOdds(key, market) {
+		selections = append(selections, models.SelectionOption{
+			Name:     odd.Name,
+			Header:   odd.Header,
+			Odds:     odd.Odds,
+			Handicap: odd.Handicap,
+		})
+	}
+
+	return models.AvailableSelection{
+		Market:     market.Name,
+		Selections: selections,
+	}
+}
+
+// FindCricketOdd returns the first priced entry of the market stored under
+// key matching the selection name and, when given, the header and handicap.
+func FindCricketOdd(key string, market cricket_models.Market, selection, header, handicap string) (cricket_models.Odd, bool) {
+	for _, odd := range MarketOdds(key, market) {
+		if odd.Name != selection {
+			continue
+		}
+		if header != "" && odd.Header != header {
+			continue
+		}
+		if handicap != "" && odd.Handicap != handicap {
+			continue
+		}
+		return odd, true
+	}
+	return cricket_models.Odd{}, false
+}
+
+func countPrices(market cricket_models.Market) int {
+	n := 0
+	for _, odd := range market.Odds {
+		if odd.Odds != "" {
+			n++
+		}
+	}
+	return n
+}
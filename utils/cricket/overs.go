@@ -0,0 +1,148 @@
+package cricket_utils
+
+import (
+	"bet365-fiber-sim/models"
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const (
+	runsInFirstOversMarket     = "Match - Runs in 1st x Overs"
+	highestFirstSixOversMarket = "Team to Make Highest 1st 6 Overs Score"
+)
+
+var (
+	firstOversRow = regexp.MustCompile(`^(\d+) Overs$`)
+	oversBowled   = regexp.MustCompile(`^(\d+)(?:\.([0-5]))?$`)
+)
+
+// The reasons RunsAfterOvers cannot tell the runs at the end of an over.
+var (
+	// errNoRunsAtOver means the innings went past the over but the result
+	// has no runs_at_over snapshot for it.
+	errNoRunsAtOver = errors.New("no over-by-over runs")
+	// errOversNotReached means the innings stopped short of the over without
+	// being completed, e.g. because of rain.
+	errOversNotReached = errors.New("overs not reached")
+)
+
+// RunsAfterOvers returns the runs of the innings at the end of the given
+// over, taken from its runs_at_over snapshots. Without a snapshot, an
+// innings that was over by the end of that over, because the side was bowled
+// out or reached its target, counts with its final score. Otherwise the
+// error wraps errNoRunsAtOver or errOversNotReached.
+func RunsAfterOvers(innings []cricket_models.Innings, index, overs int) (int, error) {
+	if index >= len(innings) {
+		return 0, fmt.Errorf("%w: the result has no innings %d", errOversNotReached, index+1)
+	}
+	inn := innings[index]
+	if len(inn.RunsAtOver) >= overs {
+		return inn.RunsAtOver[overs-1], nil
+	}
+
+	balls, ok := ballsBowled(inn.Overs)
+	if !ok {
+		return 0, fmt.Errorf("%w: team %s's innings has no runs_at_over for over %d and its overs %q are not valid",
+			errNoRunsAtOver, inn.Team, overs, inn.Overs)
+	}
+	if balls > overs*6 {
+		return 0, fmt.Errorf("%w: team %s's innings lasted %s overs but has no runs_at_over for over %d",
+			errNoRunsAtOver, inn.Team, inn.Overs, overs)
+	}
+
+	bowledOut := inn.Wickets >= 10
+	chased := index > 0 && inn.Runs > innings[index-1].Runs
+	if bowledOut || chased {
+		return inn.Runs, nil
+	}
+	return 0, fmt.Errorf("%w: team %s's innings stopped after %s overs, short of %d",
+		errOversNotReached, inn.Team, inn.Overs, overs)
+}
+
+// ballsBowled returns the legal balls in overs.balls notation, e.g. 97 for
+// "16.1".
+func ballsBowled(overs string) (int, bool) {
+	m := oversBowled.FindStringSubmatch(overs)
+	if m == nil {
+		return 0, false
+	}
+	whole, _ := strconv.Atoi(m[1])
+	balls := 0
+	if m[2] != "" {
+		balls, _ = strconv.Atoi(m[2])
+	}
+	return whole*6 + balls, true
+}
+
+// EvaluateCricketRunsInFirstOvers settles "Match - Runs in 1st x Overs", the
+// runs of the first innings after x overs. The name is the overs ("6
+// Overs"), the header Over or Under and the handicap the line.
+func EvaluateCricketRunsInFirstOvers(selection models.BetSelection, innings []cricket_models.Innings) models.EvaluationResult {
+	m := firstOversRow.FindStringSubmatch(selection.Selection)
+	if m == nil {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "invalid overs",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("Invalid overs '%s'", selection.Selection),
+		}
+	}
+	overs, _ := strconv.Atoi(m[1])
+
+	runs, err := RunsAfterOvers(innings, 0, overs)
+	if err != nil {
+		return oversVoid(selection, err)
+	}
+	return evaluateDeliveryOverUnder(selection, selection.Header, selection.Handicap, runs,
+		fmt.Sprintf("the first %d overs", overs))
+}
+
+// EvaluateCricketHighestFirstSixOvers settles "Team to Make Highest 1st 6
+// Overs Score" ("1" or "2"). Level scores are settled as a dead heat.
+func EvaluateCricketHighestFirstSixOvers(selection models.BetSelection, innings []cricket_models.Innings) models.EvaluationResult {
+	if len(innings) < 2 {
+		return oversVoid(selection, fmt.Errorf("%w: the result has no innings breakdown for both teams", errOversNotReached))
+	}
+
+	runs := make(map[string]int, 2)
+	for i := range innings[:2] {
+		r, err := RunsAfterOvers(innings, i, 6)
+		if err != nil {
+			return oversVoid(selection, err)
+		}
+		runs[innings[i].Team] = r
+	}
+
+	actual := fmt.Sprintf("%d-%d", runs["1"], runs["2"])
+	switch {
+	case runs["1"] == runs["2"]:
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: actual + " (tie)",
+			Outcome:      models.OutcomeDeadHeat,
+			Description:  fmt.Sprintf("Selected %s, both teams made %d in the first 6 overs", selection.Selection, runs["1"]),
+			DeadHeat:     2,
+		}
+	case runs["1"] > runs["2"]:
+		return selectionResult(selection, "1", actual+" (1)")
+	}
+	return selectionResult(selection, "2", actual+" (2)")
+}
+
+// oversVoid voids a selection whose runs after the overs are unknown, telling
+// missing snapshots apart from an innings cut short.
+func oversVoid(selection models.BetSelection, err error) models.EvaluationResult {
+	actual := errOversNotReached.Error()
+	if errors.Is(err, errNoRunsAtOver) {
+		actual = errNoRunsAtOver.Error()
+	}
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: actual,
+		Outcome:      "void",
+		Description:  fmt.Sprintf("The runs after the overs are unknown (%v), bets are void", err),
+	}
+}
@@ -0,0 +1,102 @@
+package cricket_utils
+
+import (
+	"errors"
+	"testing"
+
+	cricket_models "bet365-fiber-sim/models/cricket"
+)
+
+func TestRunsAfterOvers(t *testing.T) {
+	mi := cricket_models.Innings{Team: "2", Runs: 217, Wickets: 2, Overs: "20"}
+	rr := cricket_models.Innings{Team: "1", Runs: 117, Wickets: 10, Overs: "16.1"}
+	withSnapshots := rr
+	withSnapshots.RunsAtOver = []int{6, 19, 28, 36, 42, 47, 53, 60, 66, 70, 78, 87, 96, 103, 110, 116}
+
+	tests := []struct {
+		name    string
+		innings []cricket_models.Innings
+		index   int
+		overs   int
+		want    int
+		wantErr error
+	}{
+		{name: "snapshot", innings: []cricket_models.Innings{withSnapshots}, overs: 6, want: 47},
+		{name: "last snapshot", innings: []cricket_models.Innings{withSnapshots}, overs: 16, want: 116},
+		{name: "bowled out before the over", innings: []cricket_models.Innings{withSnapshots}, overs: 20, want: 117},
+		{name: "bowled out without snapshots", innings: []cricket_models.Innings{rr}, overs: 20, want: 117},
+		{name: "no snapshot for 6 overs", innings: []cricket_models.Innings{rr}, overs: 6, wantErr: errNoRunsAtOver},
+		{name: "no snapshot for 15 overs", innings: []cricket_models.Innings{rr}, overs: 15, wantErr: errNoRunsAtOver},
+		{name: "no snapshot for the over bowled out in", innings: []cricket_models.Innings{rr}, overs: 16, wantErr: errNoRunsAtOver},
+		{
+			name:    "bowled out on the last ball of the over",
+			innings: []cricket_models.Innings{{Team: "1", Runs: 98, Wickets: 10, Overs: "16"}},
+			overs:   16,
+			want:    98,
+		},
+		{
+			name:    "target reached before the over",
+			innings: []cricket_models.Innings{mi, {Team: "1", Runs: 218, Wickets: 4, Overs: "18.2"}},
+			index:   1,
+			overs:   20,
+			want:    218,
+		},
+		{
+			name:    "innings stopped short",
+			innings: []cricket_models.Innings{mi, {Team: "1", Runs: 100, Wickets: 3, Overs: "12"}},
+			index:   1,
+			overs:   15,
+			wantErr: errOversNotReached,
+		},
+		{
+			name:    "first innings stopped short",
+			innings: []cricket_models.Innings{{Team: "2", Runs: 40, Wickets: 1, Overs: "5.3"}},
+			overs:   6,
+			wantErr: errOversNotReached,
+		},
+		{name: "no such innings", innings: []cricket_models.Innings{mi}, index: 1, overs: 6, wantErr: errOversNotReached},
+		{
+			name:    "overs not given",
+			innings: []cricket_models.Innings{{Team: "1", Runs: 117, Wickets: 10}},
+			overs:   20,
+			wantErr: errNoRunsAtOver,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RunsAfterOvers(tt.innings, tt.index, tt.overs)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("RunsAfterOvers = %d, %v, want error %v", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("RunsAfterOvers = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBallsBowled(t *testing.T) {
+	tests := []struct {
+		overs string
+		want  int
+		ok    bool
+	}{
+		{"16.1", 97, true},
+		{"20", 120, true},
+		{"0.5", 5, true},
+		{"16.6", 0, false},
+		{"", 0, false},
+		{"16.1 ov", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ballsBowled(tt.overs)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ballsBowled(%q) = %d, %v, want %d, %v", tt.overs, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package cricket_utils
+
+import (
+	"bet365-fiber-sim/models"
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Player markets settled from the scorecard, by display name.
+const (
+	teamTopBatterMarket    = "Team - Top Batter"
+	teamTopBowlerMarket    = "Team - Top Bowler"
+	topMatchBatterMarket   = "Top Match Batter"
+	topMatchBowlerMarket   = "Top Match Bowler"
+	playerOfTheMatchMarket = "Player of the Match"
+)
+
+// TopBatters returns the batters with the most runs, all of them when
+// several tie. team ("1" or "2") limits the batters to one side; an empty
+// team considers both. A batter's innings are added up.
+func TopBatters(scorecard *cricket_models.Scorecard, team string) ([]string, int) {
+	runs := make(map[string]int)
+	var names []string
+	for _, batter := range scorecard.Batting {
+		if team != "" && batter.Team != team {
+			continue
+		}
+		if _, ok := runs[batter.Name]; !ok {
+			names = append(names, batter.Name)
+		}
+		runs[batter.Name] += batter.Runs
+	}
+
+	best := -1
+	var top []string
+	for _, name := range names {
+		switch {
+		case runs[name] > best:
+			best, top = runs[name], []string{name}
+		case runs[name] == best:
+			top = append(top, name)
+		}
+	}
+	return top, best
+}
+
+// TopBowlers returns the bowlers with the most wickets, fewest runs conceded
+// breaking ties; bowlers level on both are all returned. team works as for
+// TopBatters.
+func TopBowlers(scorecard *cricket_models.Scorecard, team string) ([]string, cricket_models.BowlerFigures) {
+	figures := make(map[string]cricket_models.BowlerFigures)
+	var names []string
+	for _, bowler := range scorecard.Bowling {
+		if team != "" && bowler.Team != team {
+			continue
+		}
+		total, ok := figures[bowler.Name]
+		if !ok {
+			names = append(names, bowler.Name)
+			total = cricket_models.BowlerFigures{Name: bowler.Name, Team: bowler.Team}
+		}
+		total.Wickets += bowler.Wickets
+		total.Runs += bowler.Runs
+		figures[bowler.Name] = total
+	}
+
+	var best cricket_models.BowlerFigures
+	var top []string
+	for _, name := range names {
+		f := figures[name]
+		switch {
+		case top == nil, f.Wickets > best.Wickets, f.Wickets == best.Wickets && f.Runs < best.Runs:
+			best, top = f, []string{name}
+		case f.Wickets == best.Wickets && f.Runs == best.Runs:
+			top = append(top, name)
+		}
+	}
+	return top, best
+}
+
+// EvaluateCricketPlayerMarket settles a top batter, top bowler or Player of
+// the Match selection. The team markets take the team in the header. When
+// players tie, dead heat rules apply. Players missing from the scorecard
+// lose; the market is void when the result has no scorecard.
+func EvaluateCricketPlayerMarket(selection models.BetSelection, scorecard *cricket_models.Scorecard) models.EvaluationResult {
+	if scorecard == nil {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "no scorecard",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("%s needs the scorecard, which the result does not have", selection.Market),
+		}
+	}
+
+	team := ""
+	if selection.Market == teamTopBatterMarket || selection.Market == teamTopBowlerMarket {
+		team = selection.Header
+		if team != "1" && team != "2" {
+			return models.EvaluationResult{
+				Selection:    selection,
+				ActualResult: "invalid team",
+				Outcome:      "void",
+				Description:  fmt.Sprintf("%s needs the team (1 or 2) in the header, got '%s'", selection.Market, selection.Header),
+			}
+		}
+	}
+
+	var winners []string
+	var detail string
+	switch selection.Market {
+	case teamTopBatterMarket, topMatchBatterMarket:
+		var runs int
+		winners, runs = TopBatters(scorecard, team)
+		detail = fmt.Sprintf("%d runs", runs)
+	case teamTopBowlerMarket, topMatchBowlerMarket:
+		var figures cricket_models.BowlerFigures
+		winners, figures = TopBowlers(scorecard, team)
+		detail = fmt.Sprintf("%d/%d", figures.Wickets, figures.Runs)
+	case playerOfTheMatchMarket:
+		if scorecard.PlayerOfTheMatch != "" {
+			winners = []string{scorecard.PlayerOfTheMatch}
+		}
+		detail = "player of the match"
+	}
+
+	if len(winners) == 0 {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "no winner",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("The scorecard does not settle %s, bets are void", selection.Market),
+		}
+	}
+
+	actual := fmt.Sprintf("%s (%s)", strings.Join(winners, ", "), detail)
+	switch {
+	case !slices.Contains(winners, selection.Selection):
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: actual,
+			Outcome:      "lost",
+			Description:  fmt.Sprintf("Selected %s, winner was %s", selection.Selection, strings.Join(winners, ", ")),
+		}
+	case len(winners) == 1:
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: actual,
+			Outcome:      "won",
+			Description:  fmt.Sprintf("Selected %s, winner was %s", selection.Selection, selection.Selection),
+		}
+	}
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: actual,
+		Outcome:      models.OutcomeDeadHeat,
+		Description: fmt.Sprintf("Selected %s, dead heat between %d players: %s",
+			selection.Selection, len(winners), strings.Join(winners, ", ")),
+		DeadHeat: len(winners),
+	}
+}
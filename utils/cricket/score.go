@@ -0,0 +1,100 @@
+package cricket_utils
+
+import (
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// inningsScore matches one innings: runs, optional wickets, an optional
+// declaration and optional overs, e.g. "117", "217/2", "350/6d" or
+// "117/10 (16.1)" / "117/10 (16.1 ov)".
+var inningsScore = regexp.MustCompile(`^(\d+)(?:/(\d+))?\s*(d|dec)?\s*(?:\((\d+)(?:\.([0-5]))?(?:\s*ov(?:ers)?)?\))?$`)
+
+// ParseCricketScore parses a bet365 cricket score (home first). It handles
+// runs-only scores ("117-217"), limited-overs scores with wickets and overs
+// ("117/10 (16.1) - 217/2") and multi-innings scores with the innings of a
+// team joined by "&" ("350 & 120/3d - 280 & 150/7").
+//
+// A score without wickets does not say how many fell, so Wickets is nil.
+func ParseCricketScore(ss string) (cricket_models.Score, error) {
+	sides, err := splitScoreSides(ss)
+	if err != nil {
+		return cricket_models.Score{}, err
+	}
+
+	home, err := parseSide(sides[0])
+	if err != nil {
+		return cricket_models.Score{}, err
+	}
+	away, err := parseSide(sides[1])
+	if err != nil {
+		return cricket_models.Score{}, err
+	}
+	return cricket_models.Score{Home: home, Away: away}, nil
+}
+
+// splitScoreSides splits ss on the one "-" outside parentheses.
+func splitScoreSides(ss string) ([2]string, error) {
+	depth := 0
+	split := -1
+	for i, r := range ss {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '-':
+			if depth == 0 {
+				if split >= 0 {
+					return [2]string{}, fmt.Errorf("score %q has more than two sides", ss)
+				}
+				split = i
+			}
+		}
+	}
+	if split < 0 {
+		return [2]string{}, fmt.Errorf("score %q is not in home-away format", ss)
+	}
+	return [2]string{ss[:split], ss[split+1:]}, nil
+}
+
+func parseSide(side string) ([]cricket_models.InningsScore, error) {
+	var innings []cricket_models.InningsScore
+	for _, part := range strings.Split(side, "&") {
+		score, err := ParseInningsScore(part)
+		if err != nil {
+			return nil, err
+		}
+		innings = append(innings, score)
+	}
+	return innings, nil
+}
+
+// ParseInningsScore parses a single innings such as "117/10 (16.1)".
+func ParseInningsScore(s string) (cricket_models.InningsScore, error) {
+	s = strings.TrimSpace(s)
+	m := inningsScore.FindStringSubmatch(s)
+	if m == nil {
+		return cricket_models.InningsScore{}, fmt.Errorf("innings score %q is not valid", s)
+	}
+
+	score := cricket_models.InningsScore{Declared: m[3] != ""}
+	score.Runs, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		wickets, _ := strconv.Atoi(m[2])
+		if wickets > 10 {
+			return cricket_models.InningsScore{}, fmt.Errorf("innings score %q has more than 10 wickets", s)
+		}
+		score.Wickets = &wickets
+	}
+	if m[4] != "" {
+		score.Overs, _ = strconv.Atoi(m[4])
+	}
+	if m[5] != "" {
+		score.Balls, _ = strconv.Atoi(m[5])
+	}
+	return score, nil
+}
@@ -0,0 +1,115 @@
+package cricket_utils
+
+import (
+	"reflect"
+	"testing"
+
+	cricket_models "bet365-fiber-sim/models/cricket"
+)
+
+func wickets(n int) *int {
+	return &n
+}
+
+func TestParseInningsScore(t *testing.T) {
+	tests := []struct {
+		score   string
+		want    cricket_models.InningsScore
+		wantErr bool
+	}{
+		{score: "180/7", want: cricket_models.InningsScore{Runs: 180, Wickets: wickets(7)}},
+		{score: "180", want: cricket_models.InningsScore{Runs: 180}},
+		{score: " 217/2 ", want: cricket_models.InningsScore{Runs: 217, Wickets: wickets(2)}},
+		{score: "117/10 (16.1)", want: cricket_models.InningsScore{Runs: 117, Wickets: wickets(10), Overs: 16, Balls: 1}},
+		{score: "180/7 (20 ov)", want: cricket_models.InningsScore{Runs: 180, Wickets: wickets(7), Overs: 20}},
+		{score: "350/6d", want: cricket_models.InningsScore{Runs: 350, Wickets: wickets(6), Declared: true}},
+		{score: "", wantErr: true},
+		{score: "abc", wantErr: true},
+		{score: "180/", wantErr: true},
+		{score: "180/11", wantErr: true},
+		{score: "180/7 (19.7)", wantErr: true},
+		{score: "-5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseInningsScore(tt.score)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseInningsScore(%q) = %+v, want an error", tt.score, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseInningsScore(%q): %v", tt.score, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseInningsScore(%q) = %+v, want %+v", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestParseCricketScore(t *testing.T) {
+	tests := []struct {
+		ss       string
+		want     cricket_models.Score
+		wantHome int
+		wantAway int
+		wantErr  bool
+	}{
+		{
+			ss: "117-217",
+			want: cricket_models.Score{
+				Home: []cricket_models.InningsScore{{Runs: 117}},
+				Away: []cricket_models.InningsScore{{Runs: 217}},
+			},
+			wantHome: 117,
+			wantAway: 217,
+		},
+		{
+			ss: "117/10 (16.1) - 217/2 (20)",
+			want: cricket_models.Score{
+				Home: []cricket_models.InningsScore{{Runs: 117, Wickets: wickets(10), Overs: 16, Balls: 1}},
+				Away: []cricket_models.InningsScore{{Runs: 217, Wickets: wickets(2), Overs: 20}},
+			},
+			wantHome: 117,
+			wantAway: 217,
+		},
+		{
+			ss: "350 & 120/3d - 280 & 150/7",
+			want: cricket_models.Score{
+				Home: []cricket_models.InningsScore{{Runs: 350}, {Runs: 120, Wickets: wickets(3), Declared: true}},
+				Away: []cricket_models.InningsScore{{Runs: 280}, {Runs: 150, Wickets: wickets(7)}},
+			},
+			wantHome: 470,
+			wantAway: 430,
+		},
+		{ss: "", wantErr: true},
+		{ss: "180/7", wantErr: true},
+		{ss: "1-2-3", wantErr: true},
+		{ss: "abc-217", wantErr: true},
+		{ss: "117-", wantErr: true},
+		{ss: "117 & - 217", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCricketScore(tt.ss)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseCricketScore(%q) = %+v, want an error", tt.ss, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseCricketScore(%q): %v", tt.ss, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseCricketScore(%q) = %+v, want %+v", tt.ss, got, tt.want)
+		}
+		if got.HomeRuns() != tt.wantHome || got.AwayRuns() != tt.wantAway {
+			t.Errorf("ParseCricketScore(%q) runs = %d-%d, want %d-%d",
+				tt.ss, got.HomeRuns(), got.AwayRuns(), tt.wantHome, tt.wantAway)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package cricket_utils
+
+import (
+	"bet365-fiber-sim/eventstore"
+	"bet365-fiber-sim/models"
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"bet365-fiber-sim/sports"
+	"fmt"
+)
+
+// Sport exposes cricket through the sports registry.
+type Sport struct {
+	sports.Uploads[cricket_models.PrematchEvent, cricket_models.ResultEvent]
+}
+
+func init() {
+	sports.Register(Sport{
+		Uploads: sports.Uploads[cricket_models.PrematchEvent, cricket_models.ResultEvent]{
+			Prematch: eventstore.Feed[cricket_models.PrematchEvent]{
+				Store: &uploadedPrematch,
+				Decode: func(raw []byte) ([]cricket_models.PrematchEvent, error) {
+					data, err := DecodeCricketPrematchData(raw)
+					return data.Results, err
+				},
+				Key:   func(e *cricket_models.PrematchEvent) *string { return &e.EventID },
+				Field: "event_id",
+			},
+			Result: eventstore.Feed[cricket_models.ResultEvent]{
+				Store: &uploadedResults,
+				Decode: func(raw []byte) ([]cricket_models.ResultEvent, error) {
+					data, err := DecodeCricketResultData(raw)
+					return data.Results, err
+				},
+				Key:   func(e *cricket_models.ResultEvent) *string { return &e.ID },
+				Field: "id",
+			},
+		},
+	})
+}
+
+func (Sport) Name() string {
+	return "cricket"
+}
+
+func (Sport) LoadPrematch(raw []byte) error {
+	data, err := DecodeCricketPrematchData(raw)
+	if err != nil {
+		return err
+	}
+	prematchData.Store(&data)
+	return nil
+}
+
+func (Sport) LoadResults(raw []byte) error {
+	data, err := DecodeCricketResultData(raw)
+	if err != nil {
+		return err
+	}
+	resultData.Store(&data)
+	return nil
+}
+
+func (Sport) AvailableSelections(eventID, fi string) ([]models.AvailableSelection, error) {
+	prematch := PrematchData()
+	if len(prematch.Results) == 0 {
+		return nil, fmt.Errorf("%w: no prematch data available", models.ErrEventNotFound)
+	}
+
+	event, err := FindCricketPrematchEvent(prematch, eventID, fi)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]models.AvailableSelection, 0, len(SupportedMarkets)+1)
+	for _, key := range SupportedMarkets {
+		available = append(available, GetCricketMarketSelections(event, key))
+	}
+	available = append(available, GetCricketDoubleChanceSelections(event))
+	return available, nil
+}
+
+func (Sport) BuildSelection(req models.BetEvaluationRequest) (models.BetSelection, error) {
+	return CreateCricketSelectionFromRequest(req)
+}
+
+func (Sport) Evaluate(selection models.BetSelection) (models.EvaluationResult, error) {
+	results := ResultData()
+	if len(results.Results) == 0 {
+		return models.EvaluationResult{}, fmt.Errorf("%w: no result data available", models.ErrEventNotFound)
+	}
+	return EvaluateCricketSelection(selection, results)
+}
@@ -0,0 +1,89 @@
+package cricket_utils
+
+import (
+	"bet365-fiber-sim/models"
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"fmt"
+)
+
+// TieRule decides how To Win the Match settles when the runs are level.
+type TieRule string
+
+const (
+	// TieVoid refunds every selection.
+	TieVoid TieRule = "void"
+	// TieDeadHeat pays both teams at half stake.
+	TieDeadHeat TieRule = "dead_heat"
+	// TieSuperOver settles on the super over winner, falling back to a dead
+	// heat when the result has no super over.
+	TieSuperOver TieRule = "super_over"
+)
+
+// MatchTieRule is the tie rule in force. It is set from the config at
+// startup.
+var MatchTieRule = TieSuperOver
+
+func evaluateTiedMatch(selection models.BetSelection, homeRuns, awayRuns int, superOver *cricket_models.SuperOver) models.EvaluationResult {
+	score := fmt.Sprintf("%d-%d", homeRuns, awayRuns)
+
+	// A feed that prices the tie settles it like any other outcome.
+	if selection.Selection == "X" {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: score + " (X)",
+			Outcome:      "won",
+			Description:  "Selected X, match tied",
+		}
+	}
+
+	if MatchTieRule == TieVoid {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: score + " (tie)",
+			Outcome:      "void",
+			Description:  "Match tied, bets are void",
+		}
+	}
+
+	if MatchTieRule == TieSuperOver && superOver != nil {
+		outcome := "lost"
+		if selection.Selection == superOver.Winner {
+			outcome = "won"
+		}
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: fmt.Sprintf("%s (tie, super over %s)", score, superOver.Winner),
+			Outcome:      outcome,
+			Description: fmt.Sprintf("Selected %s, match tied and %s won the super over",
+				selection.Selection, superOver.Winner),
+		}
+	}
+
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: score + " (tie)",
+		Outcome:      models.OutcomeDeadHeat,
+		Description:  fmt.Sprintf("Selected %s, match tied and dead heat rules apply", selection.Selection),
+		DeadHeat:     2,
+	}
+}
+
+// EvaluateCricketSuperOver settles To go to Super Over? ("Yes" or "No"). The
+// match went to a super over when the result has one or the runs are level.
+func EvaluateCricketSuperOver(selection models.BetSelection, homeRuns, awayRuns int, superOver *cricket_models.SuperOver) models.EvaluationResult {
+	actual := "No"
+	if superOver != nil || homeRuns == awayRuns {
+		actual = "Yes"
+	}
+	outcome := "lost"
+	if selection.Selection == actual {
+		outcome = "won"
+	}
+
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("%d-%d (%s)", homeRuns, awayRuns, actual),
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s, super over: %s", selection.Selection, actual),
+	}
+}
@@ -0,0 +1,203 @@
+package cricket_utils
+
+import (
+	"bet365-fiber-sim/models"
+	cricket_models "bet365-fiber-sim/models/cricket"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// First wicket markets, by display name.
+const (
+	firstWicketMethodMarket           = "1st Wicket Method"
+	firstWicketMethodTwoWayMarket     = "Match - 1st Wicket Method (2 Way)"
+	firstWicketMethodTeamMarket       = "Match - 1st Wicket Method - Team"
+	firstWicketMethodTeamTwoWayMarket = "Match - 1st Wicket Method - Team (2 Way)"
+	runsAtFirstWicketMarket           = "Runs at Fall of 1st Wicket"
+	runsAtFirstWicketThreeWayMarket   = "Match - Runs at Fall of First Wicket - (3 Way)"
+	runsAtFirstWicketTeamMarket       = "Match - Runs at Fall of First Wicket - Team (3 Way)"
+)
+
+var (
+	bandOverUnder = regexp.MustCompile(`^(Over|Under)\s+(\d+(?:\.\d+)?)$`)
+	bandBetween   = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*-\s*(\d+(?:\.\d+)?)$`)
+)
+
+// Band is a range of runs from a banded selection. Over and Under bounds are
+// exclusive; Between bounds are inclusive.
+type Band struct {
+	Kind string // "Over", "Under" or "Between"
+	Low  float64
+	High float64
+}
+
+// ParseBand parses a banded selection such as "Over 73.5", "Under 18" or
+// "18 - 28".
+func ParseBand(s string) (Band, error) {
+	s = strings.TrimSpace(s)
+	if m := bandOverUnder.FindStringSubmatch(s); m != nil {
+		v, _ := strconv.ParseFloat(m[2], 64)
+		if m[1] == "Over" {
+			return Band{Kind: "Over", Low: v}, nil
+		}
+		return Band{Kind: "Under", High: v}, nil
+	}
+	if m := bandBetween.FindStringSubmatch(s); m != nil {
+		low, _ := strconv.ParseFloat(m[1], 64)
+		high, _ := strconv.ParseFloat(m[2], 64)
+		if low > high {
+			return Band{}, fmt.Errorf("band %q is empty", s)
+		}
+		return Band{Kind: "Between", Low: low, High: high}, nil
+	}
+	return Band{}, fmt.Errorf("band %q is not Over N, Under N or N - M", s)
+}
+
+// Contains reports whether runs fall in the band.
+func (b Band) Contains(runs int) bool {
+	r := float64(runs)
+	switch b.Kind {
+	case "Over":
+		return r > b.Low
+	case "Under":
+		return r < b.High
+	}
+	return r >= b.Low && r <= b.High
+}
+
+// bandSelection parses the band of a 3-way selection, where the header is
+// Over, Under or Between and value the runs or range.
+func bandSelection(header, value string) (Band, error) {
+	if header == "Over" || header == "Under" {
+		return ParseBand(header + " " + value)
+	}
+	return ParseBand(value)
+}
+
+// WicketMethod maps a dismissal kind to the buckets of the 1st Wicket Method
+// markets: Caught (including caught and bowled), Bowled, LBW, Run Out,
+// Stumped or Others.
+func WicketMethod(kind string) string {
+	kind = strings.ToLower(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.TrimSpace(kind)))
+	switch kind {
+	case "caught", "caught_and_bowled", "c&b":
+		return "Caught"
+	case "bowled":
+		return "Bowled"
+	case "lbw":
+		return "LBW"
+	case "run_out":
+		return "Run Out"
+	case "stumped":
+		return "Stumped"
+	}
+	return "Others"
+}
+
+// wicketMethodTwoWay maps a dismissal kind to Caught or Any Other.
+func wicketMethodTwoWay(kind string) string {
+	if method := WicketMethod(kind); method == "Caught" {
+		return method
+	}
+	return "Any Other"
+}
+
+// teamInnings returns the first innings of team, or the first innings of the
+// match when team is empty.
+func teamInnings(innings []cricket_models.Innings, team string) (cricket_models.Innings, bool) {
+	for _, inn := range innings {
+		if team == "" || inn.Team == team {
+			return inn, true
+		}
+	}
+	return cricket_models.Innings{}, false
+}
+
+// EvaluateCricketFirstWicket settles the 1st Wicket Method and Runs at Fall
+// of First Wicket markets from the fall of wickets of the innings. Method
+// markets are void when no wicket fell; when no wicket fell the runs markets
+// use the innings total.
+func EvaluateCricketFirstWicket(selection models.BetSelection, innings []cricket_models.Innings) models.EvaluationResult {
+	team, pick := "", selection.Selection
+	switch selection.Market {
+	case firstWicketMethodTeamMarket:
+		team = selection.Header
+	case firstWicketMethodTeamTwoWayMarket, runsAtFirstWicketTeamMarket:
+		team, pick = selection.Selection, selection.Header
+	case runsAtFirstWicketThreeWayMarket:
+		pick = selection.Header
+	}
+
+	inn, ok := teamInnings(innings, team)
+	if !ok {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "no innings breakdown",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("%s needs the innings breakdown, which the result does not have", selection.Market),
+		}
+	}
+
+	switch selection.Market {
+	case firstWicketMethodMarket, firstWicketMethodTwoWayMarket, firstWicketMethodTeamMarket, firstWicketMethodTeamTwoWayMarket:
+		if len(inn.FallOfWickets) == 0 {
+			return models.EvaluationResult{
+				Selection:    selection,
+				ActualResult: "no wicket fell",
+				Outcome:      "void",
+				Description:  fmt.Sprintf("No wicket fell in the innings of team %s, bets are void", inn.Team),
+			}
+		}
+		first := inn.FallOfWickets[0]
+		actual := WicketMethod(first.Method)
+		if selection.Market == firstWicketMethodTwoWayMarket || selection.Market == firstWicketMethodTeamTwoWayMarket {
+			actual = wicketMethodTwoWay(first.Method)
+		}
+		outcome := "lost"
+		if pick == actual {
+			outcome = "won"
+		}
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: fmt.Sprintf("%s %s (%s)", first.Batter, first.Method, actual),
+			Outcome:      outcome,
+			Description:  fmt.Sprintf("Selected %s, team %s lost its first wicket %s", pick, inn.Team, actual),
+		}
+	}
+
+	runs := inn.Runs
+	if len(inn.FallOfWickets) > 0 {
+		runs = inn.FallOfWickets[0].Runs
+	}
+
+	if selection.Market == runsAtFirstWicketMarket {
+		return evaluateDeliveryOverUnder(selection, selection.Header, selection.Selection, runs,
+			fmt.Sprintf("team %s's first-wicket partnership", inn.Team))
+	}
+
+	value := selection.Selection
+	if selection.Market == runsAtFirstWicketTeamMarket {
+		value = selection.Handicap
+	}
+	band, err := bandSelection(pick, value)
+	if err != nil {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "invalid band",
+			Outcome:      "void",
+			Description:  err.Error(),
+		}
+	}
+	outcome := "lost"
+	if band.Contains(runs) {
+		outcome = "won"
+	}
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("%d runs", runs),
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s %s, team %s's first wicket fell at %d", pick, value, inn.Team, runs),
+	}
+}
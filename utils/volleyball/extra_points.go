@@ -0,0 +1,123 @@
+package volleyball_utils
+
+import (
+	"bet365-fiber-sim/models"
+	volleyball_models "bet365-fiber-sim/models/volleyball"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	extraPointsKey        = regexp.MustCompile(`^set_(\d+)_to_go_to_extra_points$`)
+	extraPointsMarketName = regexp.MustCompile(`^Set (\d+) To Go To Extra Points$`)
+)
+
+// SetTarget returns the points needed to win a set: 15 in the deciding set
+// and 25 otherwise.
+func SetTarget(set, bestOf int) int {
+	if set == bestOf {
+		return 15
+	}
+	return 25
+}
+
+// WentToExtraPoints reports whether a set went past its target. With the
+// two-point margin rule that only happens after the score reached 24-24
+// (14-14 in the deciding set), so the winner ends above the target.
+func WentToExtraPoints(set, bestOf, home, away int) bool {
+	return max(home, away) > SetTarget(set, bestOf)
+}
+
+// ParseExtraPointsMarket returns the set number of a "Set N To Go To Extra
+// Points" market.
+func ParseExtraPointsMarket(market string) (int, bool) {
+	m := extraPointsMarketName.FindStringSubmatch(market)
+	if m == nil {
+		return 0, false
+	}
+	set, _ := strconv.Atoi(m[1])
+	return set, true
+}
+
+// GetExtraPointsSelections lists a "Set N To Go To Extra Points" market for
+// every set the feed prices.
+func GetExtraPointsSelections(event *volleyball_models.PrematchEvent) []models.AvailableSelection {
+	markets := OtherMarkets(event)
+
+	var sets []int
+	for key := range markets {
+		if m := extraPointsKey.FindStringSubmatch(key); m != nil {
+			set, _ := strconv.Atoi(m[1])
+			sets = append(sets, set)
+		}
+	}
+	sort.Ints(sets)
+
+	var available []models.AvailableSelection
+	for _, set := range sets {
+		selections := []models.SelectionOption{}
+		for _, odd := range ResolveGrid(markets[fmt.Sprintf("set_%d_to_go_to_extra_points", set)]) {
+			selections = append(selections, models.SelectionOption{Name: odd.Name, Odds: odd.Odds})
+		}
+		available = append(available, models.AvailableSelection{
+			Market:     fmt.Sprintf("Set %d To Go To Extra Points", set),
+			Selections: selections,
+		})
+	}
+	return available
+}
+
+// FindExtraPointsSelection prices a "Set N To Go To Extra Points" request.
+func FindExtraPointsSelection(event *volleyball_models.PrematchEvent, req volleyball_models.BetEvaluationRequest) models.BetSelection {
+	set, ok := ParseExtraPointsMarket(req.Market)
+	if !ok {
+		return models.BetSelection{}
+	}
+	market := OtherMarkets(event)[fmt.Sprintf("set_%d_to_go_to_extra_points", set)]
+	for _, odd := range ResolveGrid(market) {
+		if odd.Name == req.Selection {
+			return models.BetSelection{
+				Market:    req.Market,
+				Selection: req.Selection,
+				Odds:      odd.Odds,
+			}
+		}
+	}
+	return models.BetSelection{}
+}
+
+// EvaluateExtraPoints settles a "Set N To Go To Extra Points" selection
+// ("Yes" or "No"). It is void when the set was not played.
+func EvaluateExtraPoints(selection models.BetSelection, scores map[string]volleyball_models.SetScore, bestOf int) models.EvaluationResult {
+	set, _ := ParseExtraPointsMarket(selection.Market)
+	score, ok := scores[strconv.Itoa(set)]
+	if !ok {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: fmt.Sprintf("set %d not played", set),
+			Outcome:      "void",
+			Description:  fmt.Sprintf("Set %d was not played, bets on it are void", set),
+		}
+	}
+	home, _ := strconv.Atoi(score.Home)
+	away, _ := strconv.Atoi(score.Away)
+
+	actual := "No"
+	if WentToExtraPoints(set, bestOf, home, away) {
+		actual = "Yes"
+	}
+	outcome := "lost"
+	if selection.Selection == actual {
+		outcome = "won"
+	}
+
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("%d-%d (%s)", home, away, actual),
+		Outcome:      outcome,
+		Description: fmt.Sprintf("Selected %s, set %d ended %d-%d against a target of %d",
+			selection.Selection, set, home, away, SetTarget(set, bestOf)),
+	}
+}
@@ -0,0 +1,94 @@
+package volleyball_utils
+
+import (
+	"testing"
+
+	"bet365-fiber-sim/models"
+	volleyball_models "bet365-fiber-sim/models/volleyball"
+)
+
+func TestWentToExtraPoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		set       int
+		bestOf    int
+		home      int
+		away      int
+		wantExtra bool
+	}{
+		{"regular set won 25-23", 1, 5, 25, 23, false},
+		{"regular set won 26-24", 1, 5, 26, 24, true},
+		{"regular set won by away 23-25", 2, 5, 23, 25, false},
+		{"regular set long deuce 31-29", 3, 5, 31, 29, true},
+		{"deciding set won 15-13", 5, 5, 15, 13, false},
+		{"deciding set won 16-14", 5, 5, 16, 14, true},
+		{"15-13 outside the deciding set is not past 25", 4, 5, 15, 13, false},
+		{"deciding set of best of three", 3, 3, 15, 13, false},
+		{"set 3 of best of five is not deciding", 3, 5, 17, 15, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WentToExtraPoints(tt.set, tt.bestOf, tt.home, tt.away); got != tt.wantExtra {
+				t.Errorf("WentToExtraPoints(%d, %d, %d, %d) = %v, want %v",
+					tt.set, tt.bestOf, tt.home, tt.away, got, tt.wantExtra)
+			}
+		})
+	}
+}
+
+func TestEvaluateExtraPoints(t *testing.T) {
+	scores := map[string]volleyball_models.SetScore{
+		"1": {Home: "25", Away: "23"},
+		"2": {Home: "24", Away: "26"},
+		"5": {Home: "15", Away: "13"},
+	}
+
+	tests := []struct {
+		name        string
+		market      string
+		selection   string
+		bestOf      int
+		wantOutcome string
+		wantActual  string
+	}{
+		{"25-23 settles No", "Set 1 To Go To Extra Points", "No", 5, "won", "25-23 (No)"},
+		{"25-23 loses Yes", "Set 1 To Go To Extra Points", "Yes", 5, "lost", "25-23 (No)"},
+		{"24-26 settles Yes", "Set 2 To Go To Extra Points", "Yes", 5, "won", "24-26 (Yes)"},
+		{"15-13 in the deciding set settles No", "Set 5 To Go To Extra Points", "No", 5, "won", "15-13 (No)"},
+		{"set not played is void", "Set 4 To Go To Extra Points", "Yes", 5, "void", "set 4 not played"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selection := models.BetSelection{Market: tt.market, Selection: tt.selection}
+			got := EvaluateExtraPoints(selection, scores, tt.bestOf)
+			if got.Outcome != tt.wantOutcome {
+				t.Errorf("Outcome = %q, want %q", got.Outcome, tt.wantOutcome)
+			}
+			if got.ActualResult != tt.wantActual {
+				t.Errorf("ActualResult = %q, want %q", got.ActualResult, tt.wantActual)
+			}
+		})
+	}
+}
+
+func TestBestOfSets(t *testing.T) {
+	tests := []struct {
+		bestOfSets string
+		want       int
+	}{
+		{"5", 5},
+		{"3", 3},
+		{"", 5},
+		{"x", 5},
+	}
+
+	for _, tt := range tests {
+		result := &volleyball_models.ResultEvent{}
+		result.Extra.BestOfSets = tt.bestOfSets
+		if got := BestOfSets(result); got != tt.want {
+			t.Errorf("BestOfSets(%q) = %d, want %d", tt.bestOfSets, got, tt.want)
+		}
+	}
+}
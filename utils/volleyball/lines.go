@@ -0,0 +1,130 @@
+package volleyball_utils
+
+import (
+	"bet365-fiber-sim/models"
+	volleyball_models "bet365-fiber-sim/models/volleyball"
+	"strings"
+)
+
+// TotalLine is one Over/Under line with its real prices. Over or Under is nil
+// when the feed only prices one side of the line.
+type TotalLine struct {
+	Line  string
+	Over  *volleyball_models.Odd
+	Under *volleyball_models.Odd
+}
+
+// GroupTotalLines pairs Over and Under prices by line value. Each source is a
+// list of Total entries whose handicap reads "O 177.5" or "U 177.5". Entries
+// are deduplicated by odd ID, so a price that bet365 repeats in several
+// sections of the feed is only counted once. Lines keep the order in which
+// they first appear.
+func GroupTotalLines(sources ...[]volleyball_models.Odd) []TotalLine {
+	seen := make(map[string]bool)
+	index := make(map[string]int)
+	var lines []TotalLine
+
+	for _, odds := range sources {
+		for _, odd := range odds {
+			if odd.Odds == "" || (odd.ID != "" && seen[odd.ID]) {
+				continue
+			}
+			side, line, ok := parseTotalHandicap(odd.Handicap)
+			if !ok {
+				continue
+			}
+			seen[odd.ID] = true
+
+			i, ok := index[line]
+			if !ok {
+				i = len(lines)
+				index[line] = i
+				lines = append(lines, TotalLine{Line: line})
+			}
+
+			odd := odd
+			switch {
+			case side == "O" && lines[i].Over == nil:
+				lines[i].Over = &odd
+			case side == "U" && lines[i].Under == nil:
+				lines[i].Under = &odd
+			}
+		}
+	}
+
+	return lines
+}
+
+// MatchTotalLines returns the match Total lines from game_lines and the
+// schedule. Set totals in "others" belong to their own set markets and are
+// not mixed in.
+func MatchTotalLines(event *volleyball_models.PrematchEvent) []TotalLine {
+	return GroupTotalLines(
+		namedOdds(ResolveGameLines(event), "Total"),
+		namedOdds(event.Schedule.Sp.Main, "Total"),
+	)
+}
+
+// FindTotalSelection prices an Over/Under request. The selection is "O" or
+// "U" (or "Over"/"Under") and the handicap is either the full "O 177.5" form
+// or just the line. The handicap may be left out when there is only one line.
+func FindTotalSelection(lines []TotalLine, req volleyball_models.BetEvaluationRequest) models.BetSelection {
+	side := strings.ToUpper(req.Selection)
+	if side == "OVER" || side == "UNDER" {
+		side = side[:1]
+	}
+
+	line := req.Handicap
+	if s, l, ok := parseTotalHandicap(req.Handicap); ok {
+		if s != side {
+			return models.BetSelection{}
+		}
+		line = l
+	}
+	if line == "" && len(lines) != 1 {
+		return models.BetSelection{}
+	}
+
+	for _, total := range lines {
+		if line != "" && total.Line != line {
+			continue
+		}
+
+		var odd *volleyball_models.Odd
+		switch side {
+		case "O":
+			odd = total.Over
+		case "U":
+			odd = total.Under
+		}
+		if odd == nil {
+			return models.BetSelection{}
+		}
+		return models.BetSelection{
+			Market:    req.Market,
+			Selection: side,
+			Odds:      odd.Odds,
+			Handicap:  odd.Handicap,
+		}
+	}
+	return models.BetSelection{}
+}
+
+// parseTotalHandicap splits "O 177.5" into its side and line.
+func parseTotalHandicap(handicap string) (string, string, bool) {
+	parts := strings.Fields(handicap)
+	if len(parts) != 2 || (parts[0] != "O" && parts[0] != "U") {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func namedOdds(odds []volleyball_models.Odd, name string) []volleyball_models.Odd {
+	var named []volleyball_models.Odd
+	for _, odd := range odds {
+		if odd.Name == name {
+			named = append(named, odd)
+		}
+	}
+	return named
+}
@@ -0,0 +1,134 @@
+package volleyball_utils
+
+import (
+	"bet365-fiber-sim/models"
+	volleyball_models "bet365-fiber-sim/models/volleyball"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	setOddEvenKey     = regexp.MustCompile(`^set_(\d+)_total_odd_even$`)
+	oddEvenMarketName = regexp.MustCompile(`^(?:Match|Set (\d+)) Total Odd/Even$`)
+)
+
+// OtherMarkets flattens the markets in "others" into one map keyed by feed
+// slug. bet365 often sends a market twice, once as an empty placeholder; the
+// copy with the most prices wins.
+func OtherMarkets(event *volleyball_models.PrematchEvent) map[string]volleyball_models.Market {
+	markets := make(map[string]volleyball_models.Market)
+	for _, other := range event.Others {
+		for key, market := range other.Sp {
+			if existing, ok := markets[key]; ok && len(ResolveGrid(existing)) >= len(ResolveGrid(market)) {
+				continue
+			}
+			markets[key] = market
+		}
+	}
+	return markets
+}
+
+// GetOddEvenSelections lists Match Total Odd/Even followed by every Set N
+// Total Odd/Even market the feed prices.
+func GetOddEvenSelections(event *volleyball_models.PrematchEvent) []models.AvailableSelection {
+	markets := OtherMarkets(event)
+
+	var available []models.AvailableSelection
+	if market, ok := markets["match_total_odd_even"]; ok {
+		available = append(available, oddEvenSelections("Match Total Odd/Even", market))
+	}
+
+	var sets []int
+	for key := range markets {
+		if m := setOddEvenKey.FindStringSubmatch(key); m != nil {
+			set, _ := strconv.Atoi(m[1])
+			sets = append(sets, set)
+		}
+	}
+	sort.Ints(sets)
+	for _, set := range sets {
+		market := markets[fmt.Sprintf("set_%d_total_odd_even", set)]
+		available = append(available, oddEvenSelections(fmt.Sprintf("Set %d Total Odd/Even", set), market))
+	}
+	return available
+}
+
+func oddEvenSelections(name string, market volleyball_models.Market) models.AvailableSelection {
+	selections := []models.SelectionOption{}
+	for _, odd := range ResolveGrid(market) {
+		selections = append(selections, models.SelectionOption{Name: odd.Name, Odds: odd.Odds})
+	}
+	return models.AvailableSelection{Market: name, Selections: selections}
+}
+
+// IsOddEvenMarket reports whether market is "Match Total Odd/Even" or
+// "Set N Total Odd/Even".
+func IsOddEvenMarket(market string) bool {
+	return oddEvenMarketName.MatchString(market)
+}
+
+// FindOddEvenSelection prices an Odd/Even request.
+func FindOddEvenSelection(event *volleyball_models.PrematchEvent, req volleyball_models.BetEvaluationRequest) models.BetSelection {
+	m := oddEvenMarketName.FindStringSubmatch(req.Market)
+	if m == nil {
+		return models.BetSelection{}
+	}
+	key := "match_total_odd_even"
+	if m[1] != "" {
+		key = fmt.Sprintf("set_%s_total_odd_even", m[1])
+	}
+
+	for _, odd := range ResolveGrid(OtherMarkets(event)[key]) {
+		if odd.Name == req.Selection {
+			return models.BetSelection{
+				Market:    req.Market,
+				Selection: req.Selection,
+				Odds:      odd.Odds,
+			}
+		}
+	}
+	return models.BetSelection{}
+}
+
+// EvaluateOddEven settles an Odd/Even selection on the total points of the
+// match or of the set named by the market. Set markets are void when the set
+// was not played.
+func EvaluateOddEven(selection models.BetSelection, scores map[string]volleyball_models.SetScore) models.EvaluationResult {
+	m := oddEvenMarketName.FindStringSubmatch(selection.Market)
+
+	var total int
+	scope := "match"
+	if m != nil && m[1] != "" {
+		scope = "set " + m[1]
+		score, ok := scores[m[1]]
+		if !ok {
+			return models.EvaluationResult{
+				Selection:    selection,
+				ActualResult: fmt.Sprintf("%s not played", scope),
+				Outcome:      "void",
+				Description:  fmt.Sprintf("Set %s was not played, bets on it are void", m[1]),
+			}
+		}
+		total = CalculateTotalPoints(map[string]volleyball_models.SetScore{m[1]: score})
+	} else {
+		total = CalculateTotalPoints(scores)
+	}
+
+	parity := "Even"
+	if total%2 != 0 {
+		parity = "Odd"
+	}
+	outcome := "lost"
+	if selection.Selection == parity {
+		outcome = "won"
+	}
+
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("%d points (%s)", total, parity),
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s, %s total was %d (%s)", selection.Selection, scope, total, parity),
+	}
+}
@@ -0,0 +1,145 @@
+package volleyball_utils
+
+import (
+	"bet365-fiber-sim/models"
+	volleyball_models "bet365-fiber-sim/models/volleyball"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	setLinesKey   = regexp.MustCompile(`^set_(\d+)_lines$`)
+	setMarketName = regexp.MustCompile(`^Set (\d+) (Winner|Total)$`)
+)
+
+// SetLines returns the "set_N_lines" markets found in others, keyed by set
+// number.
+func SetLines(event *volleyball_models.PrematchEvent) map[int]volleyball_models.Market {
+	sets := make(map[int]volleyball_models.Market)
+	for key, market := range OtherMarkets(event) {
+		if m := setLinesKey.FindStringSubmatch(key); m != nil {
+			set, _ := strconv.Atoi(m[1])
+			sets[set] = market
+		}
+	}
+	return sets
+}
+
+// ParseSetMarket splits a market name such as "Set 2 Total" into the set
+// number and the market type ("Winner" or "Total").
+func ParseSetMarket(market string) (int, string, bool) {
+	m := setMarketName.FindStringSubmatch(market)
+	if m == nil {
+		return 0, "", false
+	}
+	set, _ := strconv.Atoi(m[1])
+	return set, m[2], true
+}
+
+// GetSetSelections lists a "Set N Winner" and a "Set N Total" market for
+// every set the feed prices, in set order.
+func GetSetSelections(event *volleyball_models.PrematchEvent) []models.AvailableSelection {
+	lines := SetLines(event)
+	sets := make([]int, 0, len(lines))
+	for set := range lines {
+		sets = append(sets, set)
+	}
+	sort.Ints(sets)
+
+	var available []models.AvailableSelection
+	for _, set := range sets {
+		odds := ResolveGrid(lines[set])
+
+		winner := []models.SelectionOption{}
+		for _, odd := range odds {
+			if odd.Name == "Winner" {
+				winner = append(winner, models.SelectionOption{Name: odd.Header, Odds: odd.Odds})
+			}
+		}
+
+		total := []models.SelectionOption{}
+		for _, line := range GroupTotalLines(namedOdds(odds, "Total")) {
+			if line.Over != nil {
+				total = append(total, models.SelectionOption{Name: "O", Odds: line.Over.Odds, Handicap: line.Over.Handicap})
+			}
+			if line.Under != nil {
+				total = append(total, models.SelectionOption{Name: "U", Odds: line.Under.Odds, Handicap: line.Under.Handicap})
+			}
+		}
+
+		available = append(available,
+			models.AvailableSelection{Market: fmt.Sprintf("Set %d Winner", set), Selections: winner},
+			models.AvailableSelection{Market: fmt.Sprintf("Set %d Total", set), Selections: total},
+		)
+	}
+	return available
+}
+
+// FindSetSelection prices a "Set N Winner" or "Set N Total" request.
+func FindSetSelection(event *volleyball_models.PrematchEvent, req volleyball_models.BetEvaluationRequest) models.BetSelection {
+	set, kind, ok := ParseSetMarket(req.Market)
+	if !ok {
+		return models.BetSelection{}
+	}
+	market, ok := SetLines(event)[set]
+	if !ok {
+		return models.BetSelection{}
+	}
+	odds := ResolveGrid(market)
+
+	if kind == "Total" {
+		return FindTotalSelection(GroupTotalLines(namedOdds(odds, "Total")), req)
+	}
+	for _, odd := range odds {
+		if odd.Name == "Winner" && odd.Header == req.Selection {
+			return models.BetSelection{
+				Market:    req.Market,
+				Selection: req.Selection,
+				Odds:      odd.Odds,
+			}
+		}
+	}
+	return models.BetSelection{}
+}
+
+// EvaluateSetMarket settles a "Set N Winner" or "Set N Total" selection on
+// the points of that set. It is void when the set was not played.
+func EvaluateSetMarket(selection models.BetSelection, scores map[string]volleyball_models.SetScore) models.EvaluationResult {
+	set, kind, _ := ParseSetMarket(selection.Market)
+	score, ok := scores[strconv.Itoa(set)]
+	if !ok {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: fmt.Sprintf("set %d not played", set),
+			Outcome:      "void",
+			Description:  fmt.Sprintf("Set %d was not played, bets on it are void", set),
+		}
+	}
+	home, _ := strconv.Atoi(score.Home)
+	away, _ := strconv.Atoi(score.Away)
+
+	if kind == "Total" {
+		result := EvaluateTotal(selection, home+away)
+		if result.Outcome != "void" {
+			result.ActualResult = fmt.Sprintf("%d points (set %d: %d-%d)", home+away, set, home, away)
+		}
+		return result
+	}
+
+	winner := "1"
+	if away > home {
+		winner = "2"
+	}
+	outcome := "lost"
+	if selection.Selection == winner {
+		outcome = "won"
+	}
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("%d-%d (%s)", home, away, winner),
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s to win set %d, actual winner was %s", selection.Selection, set, winner),
+	}
+}
@@ -0,0 +1,99 @@
+package volleyball_utils
+
+import (
+	"bet365-fiber-sim/eventstore"
+	"bet365-fiber-sim/models"
+	volleyball_models "bet365-fiber-sim/models/volleyball"
+	"bet365-fiber-sim/sports"
+	"fmt"
+)
+
+// Sport exposes volleyball through the sports registry.
+type Sport struct {
+	sports.Uploads[volleyball_models.PrematchEvent, volleyball_models.ResultEvent]
+}
+
+func init() {
+	sports.Register(Sport{
+		Uploads: sports.Uploads[volleyball_models.PrematchEvent, volleyball_models.ResultEvent]{
+			Prematch: eventstore.Feed[volleyball_models.PrematchEvent]{
+				Store: &uploadedPrematch,
+				Decode: func(raw []byte) ([]volleyball_models.PrematchEvent, error) {
+					data, err := DecodePrematchData(raw)
+					return data.Results, err
+				},
+				Key:   func(e *volleyball_models.PrematchEvent) *string { return &e.EventID },
+				Field: "event_id",
+			},
+			Result: eventstore.Feed[volleyball_models.ResultEvent]{
+				Store: &uploadedResults,
+				Decode: func(raw []byte) ([]volleyball_models.ResultEvent, error) {
+					data, err := DecodeResultData(raw)
+					return data.Results, err
+				},
+				Key:   func(e *volleyball_models.ResultEvent) *string { return &e.ID },
+				Field: "id",
+			},
+		},
+	})
+}
+
+func (Sport) Name() string {
+	return "volleyball"
+}
+
+func (Sport) LoadPrematch(raw []byte) error {
+	data, err := DecodePrematchData(raw)
+	if err != nil {
+		return err
+	}
+	prematchData.Store(&data)
+	return nil
+}
+
+func (Sport) LoadResults(raw []byte) error {
+	data, err := DecodeResultData(raw)
+	if err != nil {
+		return err
+	}
+	resultData.Store(&data)
+	return nil
+}
+
+func (Sport) AvailableSelections(eventID, fi string) ([]models.AvailableSelection, error) {
+	prematch := PrematchData()
+	if len(prematch.Results) == 0 {
+		return nil, fmt.Errorf("%w: no prematch data available", models.ErrEventNotFound)
+	}
+
+	event, err := FindPrematchEvent(prematch, eventID, fi)
+	if err != nil {
+		return nil, err
+	}
+
+	available := []models.AvailableSelection{
+		Get1X2Selections(event),
+		GetTotalSelections(event),
+		GetHandicapSelections(event, "Handicap"),
+		GetCorrectScoreSelections(event),
+		GetDoubleChanceSelections(event),
+	}
+	if points := GetHandicapSelections(event, "Point Handicap"); len(points.Selections) > 0 {
+		available = append(available, points)
+	}
+	available = append(available, GetSetSelections(event)...)
+	available = append(available, GetOddEvenSelections(event)...)
+	return append(available, GetExtraPointsSelections(event)...), nil
+}
+
+func (Sport) BuildSelection(req models.BetEvaluationRequest) (models.BetSelection, error) {
+	return CreateSelectionFromRequest(req)
+}
+
+func (Sport) Evaluate(selection models.BetSelection) (models.EvaluationResult, error) {
+	results := ResultData()
+	if len(results.Results) == 0 {
+		return models.EvaluationResult{}, fmt.Errorf("%w: no result data available", models.ErrEventNotFound)
+	}
+	return EvaluateSelection(selection, results)
+}
@@ -0,0 +1,146 @@
+package volleyball_utils
+
+import (
+	"bet365-fiber-sim/models"
+	volleyball_models "bet365-fiber-sim/models/volleyball"
+	"bet365-fiber-sim/settlement"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const mostPointsOnServeMarket = "Most Points Won on Serve"
+
+var longestStreakMarketName = regexp.MustCompile(`^Longest Streak Over/Under (\d+(?:\.\d+)?)$`)
+
+// StatPair parses a [home, away] stat. It reports false when the stat is
+// absent or malformed.
+func StatPair(values []string) (int, int, bool) {
+	if len(values) != 2 {
+		return 0, 0, false
+	}
+	home, err := strconv.Atoi(values[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	away, err := strconv.Atoi(values[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return home, away, true
+}
+
+// IsStatsMarket reports whether market is settled from the match stats.
+func IsStatsMarket(market string) bool {
+	return market == mostPointsOnServeMarket || longestStreakMarketName.MatchString(market)
+}
+
+// CreateStatsSelection builds a stats market selection. The feed does not
+// price these markets, so the price is taken from the request. "Most Points
+// Won on Serve" takes "1", "2" or "Tie"; "Longest Streak Over/Under N" takes
+// "Over" or "Under".
+func CreateStatsSelection(req volleyball_models.BetEvaluationRequest) (models.BetSelection, error) {
+	valid := false
+	switch {
+	case req.Market == mostPointsOnServeMarket:
+		valid = req.Selection == "1" || req.Selection == "2" || req.Selection == "Tie"
+	case longestStreakMarketName.MatchString(req.Market):
+		valid = req.Selection == "Over" || req.Selection == "Under"
+	}
+	if !valid {
+		return models.BetSelection{}, models.ErrInvalidSelection
+	}
+	if req.Odds != "" {
+		if _, err := settlement.ParseOdds(req.Odds); err != nil {
+			return models.BetSelection{}, err
+		}
+	}
+	return models.BetSelection{
+		Market:    req.Market,
+		Selection: req.Selection,
+		Odds:      req.Odds,
+	}, nil
+}
+
+// EvaluateStatsMarket settles a stats market. It is void when the result has
+// no usable value for the stat.
+func EvaluateStatsMarket(selection models.BetSelection, stats *volleyball_models.Stats) models.EvaluationResult {
+	if selection.Market == mostPointsOnServeMarket {
+		var values []string
+		if stats != nil {
+			values = stats.PointsWonOnServe
+		}
+		home, away, ok := StatPair(values)
+		if !ok {
+			return statsVoid(selection, "points_won_on_serve")
+		}
+		return EvaluateMostPointsOnServe(selection, home, away)
+	}
+
+	var values []string
+	if stats != nil {
+		values = stats.LongestStreak
+	}
+	home, away, ok := StatPair(values)
+	if !ok {
+		return statsVoid(selection, "longest_streak")
+	}
+	return EvaluateLongestStreak(selection, home, away)
+}
+
+// EvaluateMostPointsOnServe settles "Most Points Won on Serve" ("1", "2" or
+// "Tie").
+func EvaluateMostPointsOnServe(selection models.BetSelection, home, away int) models.EvaluationResult {
+	actual := "Tie"
+	switch {
+	case home > away:
+		actual = "1"
+	case away > home:
+		actual = "2"
+	}
+	outcome := "lost"
+	if selection.Selection == actual {
+		outcome = "won"
+	}
+
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("%d-%d (%s)", home, away, actual),
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s, points won on serve were %d-%d", selection.Selection, home, away),
+	}
+}
+
+// EvaluateLongestStreak settles "Longest Streak Over/Under N" against the
+// longest run of points by either team. Whole-number lines push when hit.
+func EvaluateLongestStreak(selection models.BetSelection, home, away int) models.EvaluationResult {
+	m := longestStreakMarketName.FindStringSubmatch(selection.Market)
+	line, _ := strconv.ParseFloat(m[1], 64)
+	streak := float64(max(home, away))
+
+	outcome := "lost"
+	switch {
+	case streak == line:
+		outcome = "push"
+	case selection.Selection == "Over" && streak > line,
+		selection.Selection == "Under" && streak < line:
+		outcome = "won"
+	}
+
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("%d points", max(home, away)),
+		Outcome:      outcome,
+		Description: fmt.Sprintf("Selected %s %s, longest streaks were %d-%d",
+			selection.Selection, m[1], home, away),
+	}
+}
+
+func statsVoid(selection models.BetSelection, stat string) models.EvaluationResult {
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: fmt.Sprintf("no %s stat", stat),
+		Outcome:      "void",
+		Description:  fmt.Sprintf("The result has no %s stat, bets are void", stat),
+	}
+}
@@ -0,0 +1,196 @@
+package volleyball_utils
+
+import (
+	"bet365-fiber-sim/models"
+	volleyball_models "bet365-fiber-sim/models/volleyball"
+	"bet365-fiber-sim/settlement"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TimelineKind is the type of a result timeline event.
+type TimelineKind string
+
+const (
+	TimelineRaceTo    TimelineKind = "race_to"
+	TimelineLeadAfter TimelineKind = "lead_after"
+	TimelineTieAfter  TimelineKind = "tie_after"
+	TimelineSetWon    TimelineKind = "set_won"
+	TimelineTimeOut   TimelineKind = "time_out"
+	TimelineOther     TimelineKind = "other"
+)
+
+// TimelineEvent is a parsed result timeline event. Team is "1" for the home
+// team, "2" for the away team and empty for ties or names that match neither
+// team.
+type TimelineEvent struct {
+	ID       string
+	Kind     TimelineKind
+	Set      int
+	Points   int
+	Team     string
+	TeamName string
+	// Score is the set score of a TimelineSetWon event, e.g. "25-23".
+	Score string
+	Text  string
+}
+
+var (
+	raceToEvent    = regexp.MustCompile(`^Set (\d+) - Race to (\d+) points - (.+)$`)
+	leadAfterEvent = regexp.MustCompile(`^Set (\d+) Lead After (\d+) Points - (.+)$`)
+	tieAfterEvent  = regexp.MustCompile(`^Set (\d+) Tie After (\d+)$`)
+	setWonEvent    = regexp.MustCompile(`^Set (\d+) to (.+) - (\d+-\d+)$`)
+
+	raceToMarketName    = regexp.MustCompile(`^Race to (\d+) points in Set (\d+)$`)
+	leadAfterMarketName = regexp.MustCompile(`^Leader after (\d+) points in Set (\d+)$`)
+)
+
+// ParseTimeline parses the events of a result, mapping team names to home
+// and away.
+func ParseTimeline(result *volleyball_models.ResultEvent) []TimelineEvent {
+	events := make([]TimelineEvent, 0, len(result.Events))
+	for _, entry := range result.Events {
+		events = append(events, parseTimelineEntry(entry, result.Home.Name, result.Away.Name))
+	}
+	return events
+}
+
+func parseTimelineEntry(entry volleyball_models.TimelineEntry, home, away string) TimelineEvent {
+	event := TimelineEvent{ID: entry.ID, Kind: TimelineOther, Text: entry.Text}
+	text := strings.TrimSpace(entry.Text)
+
+	if m := raceToEvent.FindStringSubmatch(text); m != nil {
+		event.Kind = TimelineRaceTo
+		event.Set, _ = strconv.Atoi(m[1])
+		event.Points, _ = strconv.Atoi(m[2])
+		event.TeamName = m[3]
+	} else if m := leadAfterEvent.FindStringSubmatch(text); m != nil {
+		event.Kind = TimelineLeadAfter
+		event.Set, _ = strconv.Atoi(m[1])
+		event.Points, _ = strconv.Atoi(m[2])
+		event.TeamName = m[3]
+	} else if m := tieAfterEvent.FindStringSubmatch(text); m != nil {
+		event.Kind = TimelineTieAfter
+		event.Set, _ = strconv.Atoi(m[1])
+		event.Points, _ = strconv.Atoi(m[2])
+	} else if m := setWonEvent.FindStringSubmatch(text); m != nil {
+		event.Kind = TimelineSetWon
+		event.Set, _ = strconv.Atoi(m[1])
+		event.TeamName = m[2]
+		event.Score = m[3]
+	} else if strings.EqualFold(text, "Time Out") {
+		event.Kind = TimelineTimeOut
+	}
+
+	event.Team = teamSide(event.TeamName, home, away)
+	return event
+}
+
+// teamSide maps a team name to "1" (home) or "2" (away).
+func teamSide(name, home, away string) string {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return ""
+	case strings.EqualFold(name, strings.TrimSpace(home)):
+		return "1"
+	case strings.EqualFold(name, strings.TrimSpace(away)):
+		return "2"
+	}
+	return ""
+}
+
+// ParseTimelineMarket returns the event kind, points and set of a "Race to N
+// points in Set X" or "Leader after N points in Set X" market.
+func ParseTimelineMarket(market string) (TimelineKind, int, int, bool) {
+	kind := TimelineRaceTo
+	m := raceToMarketName.FindStringSubmatch(market)
+	if m == nil {
+		kind = TimelineLeadAfter
+		m = leadAfterMarketName.FindStringSubmatch(market)
+	}
+	if m == nil {
+		return "", 0, 0, false
+	}
+	points, _ := strconv.Atoi(m[1])
+	set, _ := strconv.Atoi(m[2])
+	return kind, points, set, true
+}
+
+// CreateTimelineSelection builds a timeline market selection. The feed does
+// not price these specials, so the price is taken from the request. Both
+// markets take "1" or "2"; the leader market also takes "Tie".
+func CreateTimelineSelection(req volleyball_models.BetEvaluationRequest) (models.BetSelection, error) {
+	kind, _, _, ok := ParseTimelineMarket(req.Market)
+	if !ok {
+		return models.BetSelection{}, models.ErrInvalidSelection
+	}
+	switch {
+	case req.Selection == "1", req.Selection == "2":
+	case req.Selection == "Tie" && kind == TimelineLeadAfter:
+	default:
+		return models.BetSelection{}, models.ErrInvalidSelection
+	}
+	if req.Odds != "" {
+		if _, err := settlement.ParseOdds(req.Odds); err != nil {
+			return models.BetSelection{}, err
+		}
+	}
+	return models.BetSelection{
+		Market:    req.Market,
+		Selection: req.Selection,
+		Odds:      req.Odds,
+	}, nil
+}
+
+// EvaluateTimelineMarket settles a "Race to N points in Set X" or "Leader
+// after N points in Set X" selection from the result timeline. It is void
+// when the timeline has no matching event, e.g. because the set was not
+// played or did not reach N points.
+func EvaluateTimelineMarket(selection models.BetSelection, result *volleyball_models.ResultEvent) models.EvaluationResult {
+	kind, points, set, _ := ParseTimelineMarket(selection.Market)
+
+	var found *TimelineEvent
+	for _, event := range ParseTimeline(result) {
+		if event.Set != set || event.Points != points {
+			continue
+		}
+		if event.Kind == kind || (kind == TimelineLeadAfter && event.Kind == TimelineTieAfter) {
+			found = &event
+			break
+		}
+	}
+
+	if found == nil || (found.Kind != TimelineTieAfter && found.Team == "") {
+		reason := fmt.Sprintf("no %s event for %d points in set %d", strings.ReplaceAll(string(kind), "_", " "), points, set)
+		if found != nil {
+			reason = fmt.Sprintf("team %q matches neither side", found.TeamName)
+		}
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: reason,
+			Outcome:      "void",
+			Description:  fmt.Sprintf("%s cannot be settled from the timeline, bets are void", selection.Market),
+		}
+	}
+
+	actual := found.Team
+	actualResult := fmt.Sprintf("%s (%s)", found.TeamName, found.Team)
+	if found.Kind == TimelineTieAfter {
+		actual = "Tie"
+		actualResult = "Tie"
+	}
+	outcome := "lost"
+	if selection.Selection == actual {
+		outcome = "won"
+	}
+
+	return models.EvaluationResult{
+		Selection:    selection,
+		ActualResult: actualResult,
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s, timeline: %q", selection.Selection, found.Text),
+	}
+}
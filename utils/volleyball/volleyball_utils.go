@@ -1,33 +1,56 @@
 package volleyball_utils
 
 import (
+	"bet365-fiber-sim/eventstore"
 	"bet365-fiber-sim/models"
 	volleyball_models "bet365-fiber-sim/models/volleyball"
+	"bet365-fiber-sim/pricing"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
+)
 
-	"github.com/gofiber/fiber/v2"
+// The loaded data is swapped atomically on reload, so a request always sees
+// a complete snapshot and a failed reload leaves the previous one in place.
+var (
+	prematchData atomic.Pointer[volleyball_models.PrematchResponse]
+	resultData   atomic.Pointer[volleyball_models.ResultResponse]
 )
 
-var PrematchData volleyball_models.PrematchResponse
-var ResultData volleyball_models.ResultResponse
+// Events uploaded through the admin API, overlaid on the file data.
+var (
+	uploadedPrematch eventstore.Store[volleyball_models.PrematchEvent]
+	uploadedResults  eventstore.Store[volleyball_models.ResultEvent]
+)
 
-func InitHandlers(app *fiber.App) {
-	// Load data at startup
-	var err error
-	PrematchData, err = ReadPrematchData("data/volleyball_prematch.json")
-	if err != nil {
-		panic(fmt.Sprintf("Failed to load prematch data: %v", err))
+// PrematchData returns the currently loaded prematch data, including uploaded
+// events.
+func PrematchData() volleyball_models.PrematchResponse {
+	var data volleyball_models.PrematchResponse
+	if loaded := prematchData.Load(); loaded != nil {
+		data = *loaded
 	}
+	data.Results = uploadedPrematch.Overlay(data.Results, func(e volleyball_models.PrematchEvent) string {
+		return e.EventID
+	})
+	return data
+}
 
-	ResultData, err = ReadResultData("data/volleyball_result.json")
-	if err != nil {
-		panic(fmt.Sprintf("Failed to load result data: %v", err))
+// ResultData returns the currently loaded result data, including uploaded
+// events.
+func ResultData() volleyball_models.ResultResponse {
+	var data volleyball_models.ResultResponse
+	if loaded := resultData.Load(); loaded != nil {
+		data = *loaded
 	}
+	data.Results = uploadedResults.Overlay(data.Results, func(e volleyball_models.ResultEvent) string {
+		return e.ID
+	})
+	return data
 }
 
 // func ReadJSONFile[T any](path string, target *T) error {
@@ -52,10 +75,16 @@ func ReadPrematchData(filename string) (volleyball_models.PrematchResponse, erro
 		return data, fmt.Errorf("failed to read file: %v", err)
 	}
 
-	if err := json.Unmarshal(bytes, &data); err != nil {
+	return DecodePrematchData(bytes)
+}
+
+// DecodePrematchData parses a bet365 prematch response. It is shared
+// by the file loader and the admin upload API.
+func DecodePrematchData(raw []byte) (volleyball_models.PrematchResponse, error) {
+	var data volleyball_models.PrematchResponse
+	if err := json.Unmarshal(raw, &data); err != nil {
 		return data, fmt.Errorf("failed to parse JSON: %v", err)
 	}
-
 	return data, nil
 }
 
@@ -73,83 +102,68 @@ func ReadResultData(filename string) (volleyball_models.ResultResponse, error) {
 		return data, fmt.Errorf("failed to read file: %v", err)
 	}
 
-	if err := json.Unmarshal(bytes, &data); err != nil {
+	return DecodeResultData(bytes)
+}
+
+// DecodeResultData parses a bet365 result response. It is shared
+// by the file loader and the admin upload API.
+func DecodeResultData(raw []byte) (volleyball_models.ResultResponse, error) {
+	var data volleyball_models.ResultResponse
+	if err := json.Unmarshal(raw, &data); err != nil {
 		return data, fmt.Errorf("failed to parse JSON: %v", err)
 	}
-
 	return data, nil
 }
 
-func CreateSelectionFromPrematch(data volleyball_models.PrematchResponse, market, header string, handicap ...string) models.BetSelection {
-	for _, result := range data.Results {
-		// Check main markets
-		for _, odd := range result.Main.Sp.GameLines.Odds {
-			if odd.Name == market && odd.Header == header && (len(handicap) == 0 || odd.Handicap == handicap[0]) {
-				// odds, _ := strconv.ParseFloat(odd.Odds, 64)
-				odds := odd.Odds
-
-				return models.BetSelection{
-					Market:    market,
-					Selection: header,
-					Odds:      odds,
-					Handicap:  odd.Handicap,
-				}
-			}
-		}
+// FindPrematchEvent returns the prematch event addressed by eventID and/or fi.
+// When neither is given the only loaded event is used.
+func FindPrematchEvent(data volleyball_models.PrematchResponse, eventID, fi string) (*volleyball_models.PrematchEvent, error) {
+	return models.FindEvent(data.Results, eventID, fi,
+		func(e *volleyball_models.PrematchEvent) string { return e.EventID },
+		func(e *volleyball_models.PrematchEvent) string { return e.FI })
+}
 
-		// Check schedule
-		for _, odd := range result.Schedule.Sp.Main {
-			if odd.Name == market && (len(handicap) == 0 || odd.Handicap == handicap[0]) {
-				odds := odd.Odds
-				return models.BetSelection{
-					Market:    market,
-					Selection: header,
-					Odds:      odds,
-					Handicap:  odd.Handicap,
-				}
-			}
-		}
-	}
-	return models.BetSelection{}
+// FindResultEvent returns the result for the event addressed by eventID
+// (result id) and/or fi (result bet365_id).
+func FindResultEvent(data volleyball_models.ResultResponse, eventID, fi string) (*volleyball_models.ResultEvent, error) {
+	return models.FindEvent(data.Results, eventID, fi,
+		func(e *volleyball_models.ResultEvent) string { return e.ID },
+		func(e *volleyball_models.ResultEvent) string { return e.Bet365ID })
 }
 
-func CreateCorrectScoreSelection(data volleyball_models.PrematchResponse, header, score string) models.BetSelection {
-	for _, result := range data.Results {
-		for _, odd := range result.Main.Sp.CorrectSetScore.Odds {
-			if odd.Header == header && odd.Name == score {
-				odds := odd.Odds
-				return models.BetSelection{
-					Market:    "Correct Set Score",
-					Selection: score,
-					Odds:      odds,
-				}
-			}
-		}
+// EvaluateSelection settles the selection against the result of the event it
+// was priced on. It is an error if that event has no result. The match stats
+// are attached to the evaluation when the result has them.
+func EvaluateSelection(selection models.BetSelection, resultData volleyball_models.ResultResponse) (models.EvaluationResult, error) {
+	result, err := FindResultEvent(resultData, selection.EventID, selection.FI)
+	if err != nil {
+		return models.EvaluationResult{}, err
 	}
-	return models.BetSelection{}
-}
 
-func CreateDoubleChanceSelection(data volleyball_models.PrematchResponse, combo string) models.BetSelection {
-	// In a real implementation, you would look up the actual odds for these combinations
-	// This is a simplified version for demonstration
-	return models.BetSelection{
-		Market:    "Double Chance",
-		Selection: combo,
-		Odds:      "1.25", // Example odds
+	evaluation := evaluateResult(selection, result)
+	if result.Stats != nil {
+		evaluation.Stats = result.Stats
 	}
+	return evaluation, nil
 }
 
-func EvaluateSelection(selection models.BetSelection, resultData volleyball_models.ResultResponse) models.EvaluationResult {
-	if len(resultData.Results) == 0 {
-		return models.EvaluationResult{
-			Selection:    selection,
-			ActualResult: "no result data",
-			Outcome:      "void",
-			Description:  "No result data available",
-		}
+func evaluateResult(selection models.BetSelection, result *volleyball_models.ResultEvent) models.EvaluationResult {
+	if _, _, ok := ParseSetMarket(selection.Market); ok {
+		return EvaluateSetMarket(selection, result.Scores)
+	}
+	if IsOddEvenMarket(selection.Market) {
+		return EvaluateOddEven(selection, result.Scores)
+	}
+	if _, ok := ParseExtraPointsMarket(selection.Market); ok {
+		return EvaluateExtraPoints(selection, result.Scores, BestOfSets(result))
+	}
+	if _, _, _, ok := ParseTimelineMarket(selection.Market); ok {
+		return EvaluateTimelineMarket(selection, result)
+	}
+	if IsStatsMarket(selection.Market) {
+		return EvaluateStatsMarket(selection, result.Stats)
 	}
 
-	result := resultData.Results[0]
 	totalPoints := CalculateTotalPoints(result.Scores)
 	homeSets, awaySets := ParseSetScore(result.SS)
 
@@ -158,6 +172,11 @@ func EvaluateSelection(selection models.BetSelection, resultData volleyball_mode
 		return Evaluate1X2(selection, homeSets, awaySets)
 	case "Total":
 		return EvaluateTotal(selection, totalPoints)
+	case "Handicap":
+		return EvaluateHandicap(selection, float64(homeSets), float64(awaySets), "sets")
+	case "Point Handicap":
+		homePoints, awayPoints := CalculateTeamPoints(result.Scores)
+		return EvaluateHandicap(selection, float64(homePoints), float64(awayPoints), "points")
 	case "Correct Set Score":
 		return EvaluateCorrectScore(selection, homeSets, awaySets)
 	case "Double Chance":
@@ -250,29 +269,12 @@ func EvaluateTotal(selection models.BetSelection, totalPoints int) models.Evalua
 	}
 }
 
-func EvaluateWinner(selection models.BetSelection, homeSets, awaySets int) models.EvaluationResult {
-	var winner string
-	if homeSets > awaySets {
-		winner = "1"
-	} else {
-		winner = "2"
-	}
-
-	outcome := "lost"
-	if selection.Selection == winner {
-		outcome = "won"
-	}
-
-	return models.EvaluationResult{
-		Selection:    selection,
-		ActualResult: fmt.Sprintf("%d-%d", homeSets, awaySets),
-		Outcome:      outcome,
-		Description:  fmt.Sprintf("Selected %s to win, actual result was %d-%d", selection.Selection, homeSets, awaySets),
-	}
-}
-
-func EvaluateHandicap(selection models.BetSelection, homeSets, awaySets int, scores map[string]volleyball_models.SetScore) models.EvaluationResult {
-	// First check if we even have a handicap value
+// EvaluateHandicap settles a handicap selection, applying the line to the
+// selected side ("1" home, "2" away) of the home and away score, counted in
+// unit. The market decides the unit: the game lines "Handicap" is a set
+// handicap and "Point Handicap" is settled on the points each team scored
+// across all sets. Whole-number lines push when the adjusted scores are level.
+func EvaluateHandicap(selection models.BetSelection, home, away float64, unit string) models.EvaluationResult {
 	if selection.Handicap == "" {
 		return models.EvaluationResult{
 			Selection:    selection,
@@ -282,66 +284,47 @@ func EvaluateHandicap(selection models.BetSelection, homeSets, awaySets int, sco
 		}
 	}
 
-	// Try to parse the handicap value
-	var handicapValue float64
-	var err error
-
-	// Check if it's a simple number (like "-1.5")
-	if strings.HasPrefix(selection.Handicap, "-") || strings.HasPrefix(selection.Handicap, "+") {
-		handicapValue, err = strconv.ParseFloat(selection.Handicap, 64)
-		if err != nil {
-			return models.EvaluationResult{
-				Selection:    selection,
-				ActualResult: "invalid handicap format",
-				Outcome:      "void",
-				Description:  fmt.Sprintf("Failed to parse handicap value '%s': %v", selection.Handicap, err),
-			}
-		}
-	} else {
-		// Handle cases like "O 177.5" or "U 177.5"
-		parts := strings.Fields(selection.Handicap)
-		if len(parts) < 2 {
-			return models.EvaluationResult{
-				Selection:    selection,
-				ActualResult: "invalid handicap format",
-				Outcome:      "void",
-				Description:  fmt.Sprintf("Handicap format is invalid: '%s'", selection.Handicap),
-			}
-		}
-
-		handicapValue, err = strconv.ParseFloat(parts[1], 64)
-		if err != nil {
-			return models.EvaluationResult{
-				Selection:    selection,
-				ActualResult: "invalid handicap value",
-				Outcome:      "void",
-				Description:  fmt.Sprintf("Failed to parse handicap value '%s': %v", parts[1], err),
-			}
+	handicap, err := strconv.ParseFloat(selection.Handicap, 64)
+	if err != nil {
+		return models.EvaluationResult{
+			Selection:    selection,
+			ActualResult: "invalid handicap format",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("Failed to parse handicap value '%s': %v", selection.Handicap, err),
 		}
 	}
 
-	// For set handicap (like -1.5)
-	if strings.Contains(selection.Handicap, ".") {
-		adjustedHome := float64(homeSets) - handicapValue
-		outcome := "lost"
-		if adjustedHome > float64(awaySets) {
-			outcome = "won"
-		}
-
+	adjustedHome, adjustedAway := home, away
+	var selected, opponent float64
+	switch selection.Selection {
+	case "1":
+		adjustedHome += handicap
+		selected, opponent = adjustedHome, adjustedAway
+	case "2":
+		adjustedAway += handicap
+		selected, opponent = adjustedAway, adjustedHome
+	default:
 		return models.EvaluationResult{
 			Selection:    selection,
-			ActualResult: fmt.Sprintf("%d-%d (handicap adjusted: %.1f-%d)", homeSets, awaySets, adjustedHome, awaySets),
-			Outcome:      outcome,
-			Description:  fmt.Sprintf("Selected %s with handicap %s", selection.Selection, selection.Handicap),
+			ActualResult: "invalid handicap selection",
+			Outcome:      "void",
+			Description:  fmt.Sprintf("Invalid handicap selection '%s' (must be 1 or 2)", selection.Selection),
 		}
 	}
 
-	// For point handicap (would need to calculate total points per team)
+	outcome := "lost"
+	if selected > opponent {
+		outcome = "won"
+	} else if selected == opponent {
+		outcome = "push"
+	}
+
+	adjusted := fmt.Sprintf("%s-%s", formatScore(adjustedHome), formatScore(adjustedAway))
 	return models.EvaluationResult{
 		Selection:    selection,
-		ActualResult: "point handicap not implemented",
-		Outcome:      "void",
-		Description:  "Point handicap evaluation not implemented",
+		ActualResult: fmt.Sprintf("%s-%s %s (handicap adjusted: %s)", formatScore(home), formatScore(away), unit, adjusted),
+		Outcome:      outcome,
+		Description:  fmt.Sprintf("Selected %s with %s handicap %s, adjusted result was %s", selection.Selection, strings.TrimSuffix(unit, "s"), selection.Handicap, adjusted),
 	}
 }
 
@@ -394,10 +377,7 @@ func EvaluateDoubleChance(selection models.BetSelection, homeSets, awaySets int)
 	}
 }
 
-func CalculateTotalPoints(scores map[string]struct {
-	Home string `json:"home"`
-	Away string `json:"away"`
-}) int {
+func CalculateTotalPoints(scores map[string]volleyball_models.SetScore) int {
 	total := 0
 	for _, set := range scores {
 		home, _ := strconv.Atoi(set.Home)
@@ -407,6 +387,32 @@ func CalculateTotalPoints(scores map[string]struct {
 	return total
 }
 
+// CalculateTeamPoints returns the points scored by each team across all sets.
+func CalculateTeamPoints(scores map[string]volleyball_models.SetScore) (int, int) {
+	home, away := 0, 0
+	for _, set := range scores {
+		h, _ := strconv.Atoi(set.Home)
+		a, _ := strconv.Atoi(set.Away)
+		home += h
+		away += a
+	}
+	return home, away
+}
+
+// BestOfSets returns the length of the match in sets, assuming best of five
+// when the result does not say.
+func BestOfSets(result *volleyball_models.ResultEvent) int {
+	bestOf, err := strconv.Atoi(result.Extra.BestOfSets)
+	if err != nil || bestOf <= 0 {
+		return 5
+	}
+	return bestOf
+}
+
+func formatScore(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
 func ParseSetScore(ss string) (int, int) {
 	parts := strings.Split(ss, "-")
 	if len(parts) != 2 {
@@ -417,88 +423,149 @@ func ParseSetScore(ss string) (int, int) {
 	return home, away
 }
 
-func CreateSelectionFromRequest(req volleyball_models.BetEvaluationRequest) models.BetSelection {
+// CreateSelectionFromRequest prices the requested selection from the prematch
+// data of the event the request points at.
+func CreateSelectionFromRequest(req volleyball_models.BetEvaluationRequest) (models.BetSelection, error) {
+	event, err := FindPrematchEvent(PrematchData(), req.EventID, req.FI)
+	if err != nil {
+		return models.BetSelection{}, err
+	}
+
+	var selection models.BetSelection
 	switch req.Market {
-	case "Winner", "Total":
-		return FindSelectionInPrematch(req)
+	case "Winner", "Handicap", "Point Handicap":
+		selection = FindSelectionInPrematch(event, req)
+	case "Total":
+		selection = FindTotalSelection(MatchTotalLines(event), req)
 	case "Correct Set Score":
-		return FindCorrectScoreSelection(req)
+		selection = FindCorrectScoreSelection(event, req)
 	case "Double Chance":
-		return models.BetSelection{
+		option, err := pricing.FindSelection(GetDoubleChanceSelections(event), req.Selection)
+		if err != nil {
+			return selection, err
+		}
+		selection = models.BetSelection{
 			Market:    req.Market,
 			Selection: req.Selection,
-			Odds:      GetDoubleChanceOdds(req.Selection),
+			Odds:      option.Odds,
 		}
 	default:
-		return models.BetSelection{}
+		if _, _, ok := ParseSetMarket(req.Market); ok {
+			selection = FindSetSelection(event, req)
+		} else if IsOddEvenMarket(req.Market) {
+			selection = FindOddEvenSelection(event, req)
+		} else if _, ok := ParseExtraPointsMarket(req.Market); ok {
+			selection = FindExtraPointsSelection(event, req)
+		} else if _, _, _, ok := ParseTimelineMarket(req.Market); ok {
+			if selection, err = CreateTimelineSelection(req); err != nil {
+				return selection, err
+			}
+		} else if IsStatsMarket(req.Market) {
+			if selection, err = CreateStatsSelection(req); err != nil {
+				return selection, err
+			}
+		}
 	}
+	if selection.Market == "" {
+		return selection, models.ErrInvalidSelection
+	}
+
+	selection.EventID = event.EventID
+	selection.FI = event.FI
+	return selection, nil
+}
+
+// ResolveGameLines returns the priced game lines with their market names
+// filled in (see ResolveGrid).
+func ResolveGameLines(event *volleyball_models.PrematchEvent) []volleyball_models.Odd {
+	return ResolveGrid(event.Main.Sp.GameLines)
 }
 
-func FindSelectionInPrematch(req volleyball_models.BetEvaluationRequest) models.BetSelection {
-	for _, result := range PrematchData.Results {
-		// Check game lines
-		for _, odd := range result.Main.Sp.GameLines.Odds {
-			if odd.Name == req.Market &&
-				(odd.Header == req.Selection || odd.Name == req.Selection) &&
-				(req.Handicap == "" || odd.Handicap == req.Handicap) {
-				odds := odd.Odds
-				return models.BetSelection{
-					Market:    req.Market,
-					Selection: req.Selection,
-					Odds:      odds,
-					Handicap:  odd.Handicap,
-				}
+// ResolveGrid returns the priced entries of a market with their names filled
+// in. bet365 sends line markets such as game_lines as a grid: one "PC<id>"
+// row per market ("Winner", "Handicap", "Total") followed by the prices
+// column by column. A price is matched to its row by ID when possible,
+// otherwise by position. Markets without PC rows are returned as they are.
+func ResolveGrid(market volleyball_models.Market) []volleyball_models.Odd {
+	rowsByID := make(map[string]volleyball_models.Odd)
+	var rows []volleyball_models.Odd
+	var resolved []volleyball_models.Odd
+	column := 0
+
+	for _, odd := range market.Odds {
+		if strings.HasPrefix(odd.ID, "PC") {
+			rows = append(rows, odd)
+			rowsByID[strings.TrimPrefix(odd.ID, "PC")] = odd
+			continue
+		}
+		if len(rows) == 0 {
+			if odd.Odds != "" {
+				resolved = append(resolved, odd)
 			}
+			continue
+		}
+
+		row, ok := rowsByID[odd.ID]
+		if !ok {
+			row = rows[column%len(rows)]
+		}
+		column++
+		if odd.Odds == "" {
+			continue
 		}
+		odd.Name = row.Name
+		resolved = append(resolved, odd)
+	}
+
+	return resolved
+}
 
-		// Check schedule
-		for _, odd := range result.Schedule.Sp.Main {
-			if odd.Name == req.Market &&
-				(req.Handicap == "" || odd.Handicap == req.Handicap) {
-				odds := odd.Odds
-				return models.BetSelection{
-					Market:    req.Market,
-					Selection: req.Selection,
-					Odds:      odds,
-					Handicap:  odd.Handicap,
-				}
+func FindSelectionInPrematch(event *volleyball_models.PrematchEvent, req volleyball_models.BetEvaluationRequest) models.BetSelection {
+	// Check game lines
+	for _, odd := range ResolveGameLines(event) {
+		if odd.Name == req.Market &&
+			odd.Header == req.Selection &&
+			(req.Handicap == "" || odd.Handicap == req.Handicap) {
+			return models.BetSelection{
+				Market:    req.Market,
+				Selection: req.Selection,
+				Odds:      odd.Odds,
+				Handicap:  odd.Handicap,
+			}
+		}
+	}
+
+	// Check schedule. Its rows carry no side: each market lists team 1's
+	// row first and team 2's second, as the game lines columns do.
+	rows := make(map[string]int)
+	for _, odd := range event.Schedule.Sp.Main {
+		rows[odd.Name]++
+		side := strconv.Itoa(rows[odd.Name])
+		if odd.Name == req.Market &&
+			side == req.Selection &&
+			(req.Handicap == "" || odd.Handicap == req.Handicap) {
+			odds := odd.Odds
+			return models.BetSelection{
+				Market:    req.Market,
+				Selection: req.Selection,
+				Odds:      odds,
+				Handicap:  odd.Handicap,
 			}
 		}
 	}
 	return models.BetSelection{}
 }
 
-func Get1X2Selections() models.AvailableSelection {
-	selections := []struct {
-		Name     string `json:"name"`
-		Odds     string `json:"odds"`
-		Handicap string `json:"handicap,omitempty"`
-	}{}
-
-	for _, result := range PrematchData.Results {
-		for _, other := range result.Others {
-			// Check Set1Lines in Others
-			if other.Sp.Set1Lines.Odds != nil {
-				for _, odd := range other.Sp.Set1Lines.Odds {
-					if odd.Name == "Winner" && odd.Odds != "" {
-						odds := odd.Odds
-						{
-							selections = append(selections, struct {
-								Name     string `json:"name"`
-								Odds     string `json:"odds"`
-								Handicap string `json:"handicap,omitempty"`
-							}{
-								Name:     odd.Header, // "1" or "2"
-								Odds:     odds,
-								Handicap: odd.Handicap,
-							})
-						}
-					}
-				}
-			}
+func Get1X2Selections(event *volleyball_models.PrematchEvent) models.AvailableSelection {
+	selections := []models.SelectionOption{}
 
-			// Add checks for other markets in Others if needed
-			// e.g., other.Sp.Set1ToGoToExtraPoints, etc.
+	for _, odd := range ResolveGameLines(event) {
+		if odd.Name == "Winner" {
+			selections = append(selections, models.SelectionOption{
+				Name:     odd.Header, // "1" or "2"
+				Odds:     odd.Odds,
+				Handicap: odd.Handicap,
+			})
 		}
 	}
 
@@ -511,21 +578,9 @@ func Get1X2Selections() models.AvailableSelection {
 	}
 }
 
-func removeDuplicateSelections(selections []struct {
-	Name     string `json:"name"`
-	Odds     string `json:"odds"`
-	Handicap string `json:"handicap,omitempty"`
-}) []struct {
-	Name     string `json:"name"`
-	Odds     string `json:"odds"`
-	Handicap string `json:"handicap,omitempty"`
-} {
+func removeDuplicateSelections(selections []models.SelectionOption) []models.SelectionOption {
 	keys := make(map[string]bool)
-	list := []struct {
-		Name     string `json:"name"`
-		Odds     string `json:"odds"`
-		Handicap string `json:"handicap,omitempty"`
-	}{}
+	list := []models.SelectionOption{}
 	for _, entry := range selections {
 		if _, value := keys[entry.Name]; !value {
 			keys[entry.Name] = true
@@ -535,100 +590,60 @@ func removeDuplicateSelections(selections []struct {
 	return list
 }
 
-func GetDoubleChanceSelections() models.AvailableSelection {
-	return models.AvailableSelection{
-		Market: "Double Chance",
-		Selections: []struct {
-			Name     string `json:"name"`
-			Odds     string `json:"odds"`
-			Handicap string `json:"handicap,omitempty"`
-		}{
-			{Name: "1X", Odds: "1.10"},
-			{Name: "12", Odds: "1.05"},
-			{Name: "X2", Odds: "1.20"},
-		},
+// GetDoubleChanceSelections returns the Double Chance market, priced from the
+// feed when it carries one and otherwise derived from the match Winner
+// prices. Volleyball cannot be drawn, so without feed prices the market is
+// unavailable.
+func GetDoubleChanceSelections(event *volleyball_models.PrematchEvent) models.AvailableSelection {
+	feed := []models.SelectionOption{}
+	for _, odd := range event.Main.Sp.DoubleChance.Odds {
+		if odd.Odds != "" {
+			feed = append(feed, models.SelectionOption{Name: odd.Name, Odds: odd.Odds})
+		}
 	}
+
+	winner := []models.SelectionOption{}
+	for _, odd := range ResolveGameLines(event) {
+		if odd.Name == "Winner" {
+			winner = append(winner, models.SelectionOption{Name: odd.Header, Odds: odd.Odds})
+		}
+	}
+
+	return pricing.DoubleChanceMarket(feed, winner)
 }
 
-func FindCorrectScoreSelection(req volleyball_models.BetEvaluationRequest) models.BetSelection {
-	for _, result := range PrematchData.Results {
-		for _, odd := range result.Main.Sp.CorrectSetScore.Odds {
-			if odd.Header == req.Selection && odd.Name == req.ScoreLine {
-				odds := odd.Odds
-				return models.BetSelection{
-					Market:    req.Market,
-					Selection: req.Selection,
-					Odds:      odds,
-					ScoreLine: odd.Name,
-				}
+func FindCorrectScoreSelection(event *volleyball_models.PrematchEvent, req volleyball_models.BetEvaluationRequest) models.BetSelection {
+	for _, odd := range event.Main.Sp.CorrectSetScore.Odds {
+		if odd.Header == req.Selection && odd.Name == req.ScoreLine {
+			odds := odd.Odds
+			return models.BetSelection{
+				Market:    req.Market,
+				Selection: req.Selection,
+				Odds:      odds,
+				ScoreLine: odd.Name,
 			}
 		}
 	}
 	return models.BetSelection{}
 }
 
-func GetDoubleChanceOdds(selection string) string {
-	// In a real implementation, you would get these from the prematch data
-	// This is a simplified version with example odds
-	switch selection {
-	case "1X":
-		return "1.10"
-	case "12":
-		return "1.05"
-	case "X2":
-		return "1.20"
-	default:
-		return "0"
-	}
-}
+func GetTotalSelections(event *volleyball_models.PrematchEvent) models.AvailableSelection {
+	selections := []models.SelectionOption{}
 
-func GetTotalSelections() models.AvailableSelection {
-	selections := []struct {
-		Name     string `json:"name"`
-		Odds     string `json:"odds"`
-		Handicap string `json:"handicap,omitempty"`
-	}{}
-
-	for _, result := range PrematchData.Results {
-		for _, odd := range result.Main.Sp.GameLines.Odds {
-			if odd.Name == "Total" && odd.Header != "" {
-				odds := odd.Odds
-				selections = append(selections, struct {
-					Name     string `json:"name"`
-					Odds     string `json:"odds"`
-					Handicap string `json:"handicap,omitempty"`
-				}{
-					Name:     odd.Header,
-					Odds:     odds,
-					Handicap: odd.Handicap,
-				})
-			}
+	for _, line := range MatchTotalLines(event) {
+		if line.Over != nil {
+			selections = append(selections, models.SelectionOption{
+				Name:     "O",
+				Odds:     line.Over.Odds,
+				Handicap: line.Over.Handicap,
+			})
 		}
-
-		for _, odd := range result.Schedule.Sp.Main {
-			if odd.Name == "Total" {
-				odds := odd.Odds
-				selections = append(selections, struct {
-					Name     string `json:"name"`
-					Odds     string `json:"odds"`
-					Handicap string `json:"handicap,omitempty"`
-				}{
-					Name:     "O",
-					Odds:     odds,
-					Handicap: odd.Handicap,
-				})
-				// Add under selection
-				underOdds := "1.83" // Example - in real implementation you'd get this from data
-				selections = append(selections, struct {
-					Name     string `json:"name"`
-					Odds     string `json:"odds"`
-					Handicap string `json:"handicap,omitempty"`
-				}{
-					Name:     "U",
-					Odds:     underOdds,
-					Handicap: odd.Handicap,
-				})
-			}
+		if line.Under != nil {
+			selections = append(selections, models.SelectionOption{
+				Name:     "U",
+				Odds:     line.Under.Odds,
+				Handicap: line.Under.Handicap,
+			})
 		}
 	}
 
@@ -638,28 +653,39 @@ func GetTotalSelections() models.AvailableSelection {
 	}
 }
 
-func GetCorrectScoreSelections() models.AvailableSelection {
-	selections := []struct {
-		Name     string `json:"name"`
-		Odds     string `json:"odds"`
-		Handicap string `json:"handicap,omitempty"`
-	}{}
-
-	for _, result := range PrematchData.Results {
-		for _, odd := range result.Main.Sp.CorrectSetScore.Odds {
-			odds := odd.Odds
-			selections = append(selections, struct {
-				Name     string `json:"name"`
-				Odds     string `json:"odds"`
-				Handicap string `json:"handicap,omitempty"`
-			}{
-				Name:     odd.Name,
-				Odds:     odds,
-				Handicap: odd.Header, // Using Header to indicate home/away
+// GetHandicapSelections lists the game lines prices of a handicap market,
+// "Handicap" (sets) or "Point Handicap".
+func GetHandicapSelections(event *volleyball_models.PrematchEvent, market string) models.AvailableSelection {
+	selections := []models.SelectionOption{}
+
+	for _, odd := range ResolveGameLines(event) {
+		if odd.Name == market {
+			selections = append(selections, models.SelectionOption{
+				Name:     odd.Header,
+				Odds:     odd.Odds,
+				Handicap: odd.Handicap,
 			})
 		}
 	}
 
+	return models.AvailableSelection{
+		Market:     market,
+		Selections: selections,
+	}
+}
+
+func GetCorrectScoreSelections(event *volleyball_models.PrematchEvent) models.AvailableSelection {
+	selections := []models.SelectionOption{}
+
+	for _, odd := range event.Main.Sp.CorrectSetScore.Odds {
+		odds := odd.Odds
+		selections = append(selections, models.SelectionOption{
+			Name:     odd.Name,
+			Odds:     odds,
+			Handicap: odd.Header, // Using Header to indicate home/away
+		})
+	}
+
 	return models.AvailableSelection{
 		Market:     "Correct Set Score",
 		Selections: selections,
@@ -0,0 +1,185 @@
+// Package watcher reloads data files when they change on disk.
+//
+// Files are polled rather than watched with inotify so that changes made
+// through docker volume mounts, which do not always raise events inside the
+// container, are picked up too.
+package watcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// LoadFunc parses the content of a watched file and, on success, replaces
+// the data it feeds. On failure it must leave the current data untouched.
+type LoadFunc func(raw []byte) error
+
+// FileStatus describes a watched file and the snapshot loaded from it.
+type FileStatus struct {
+	Sport       string     `json:"sport"`
+	Kind        string     `json:"kind"`
+	Path        string     `json:"path"`
+	SHA256      string     `json:"sha256"`
+	Size        int64      `json:"size"`
+	ModifiedAt  time.Time  `json:"modified_at"`
+	LoadedAt    time.Time  `json:"loaded_at"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+type file struct {
+	status FileStatus
+	load   LoadFunc
+}
+
+// Watcher keeps a set of data files loaded.
+type Watcher struct {
+	interval time.Duration
+
+	mu    sync.RWMutex
+	files []*file
+}
+
+// New returns a watcher that polls every interval. An interval of zero
+// disables polling; files are then only loaded by Add.
+func New(interval time.Duration) *Watcher {
+	return &Watcher{interval: interval}
+}
+
+// Add loads the file at path and watches it from then on. The initial load
+// must succeed.
+func (w *Watcher) Add(sport, kind, path string, load LoadFunc) error {
+	f := &file{
+		status: FileStatus{Sport: sport, Kind: kind, Path: path},
+		load:   load,
+	}
+	if _, err := w.reload(f); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.files = append(w.files, f)
+	w.mu.Unlock()
+	return nil
+}
+
+// Run polls the watched files until ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// Status returns the state of every watched file.
+func (w *Watcher) Status() []FileStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	statuses := make([]FileStatus, 0, len(w.files))
+	for _, f := range w.files {
+		statuses = append(statuses, f.status)
+	}
+	return statuses
+}
+
+func (w *Watcher) poll() {
+	w.mu.RLock()
+	files := append([]*file(nil), w.files...)
+	w.mu.RUnlock()
+
+	for _, f := range files {
+		w.mu.RLock()
+		status := f.status
+		w.mu.RUnlock()
+
+		info, err := os.Stat(status.Path)
+		if err != nil && status.LastError != "" {
+			// Already reported; wait for the file to come back.
+			continue
+		}
+		if err == nil && info.ModTime().Equal(status.ModifiedAt) && info.Size() == status.Size {
+			continue
+		}
+
+		changed, err := w.reload(f)
+		if err != nil {
+			slog.Warn("reload failed, keeping previous data",
+				"sport", status.Sport, "kind", status.Kind, "path", status.Path, "error", err)
+			continue
+		}
+		if changed {
+			slog.Info("reloaded data", "sport", status.Sport, "kind", status.Kind, "path", status.Path)
+		}
+	}
+}
+
+// reload hashes the file and, if its content changed, loads it. The size
+// and modification time are recorded even when loading fails, so a broken
+// file is retried only once it changes again. It reports whether new data
+// was loaded.
+func (w *Watcher) reload(f *file) (bool, error) {
+	w.mu.RLock()
+	status := f.status
+	w.mu.RUnlock()
+
+	changed := false
+	err := func() error {
+		info, err := os.Stat(status.Path)
+		if err != nil {
+			return err
+		}
+		status.Size = info.Size()
+		status.ModifiedAt = info.ModTime()
+
+		raw, err := os.ReadFile(status.Path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(raw)
+		hash := hex.EncodeToString(sum[:])
+		if hash == status.SHA256 {
+			return nil
+		}
+
+		if err := f.load(raw); err != nil {
+			return err
+		}
+		status.SHA256 = hash
+		status.LoadedAt = time.Now()
+		changed = true
+		return nil
+	}()
+
+	if err != nil {
+		now := time.Now()
+		status.LastError = err.Error()
+		status.LastErrorAt = &now
+		err = fmt.Errorf("%s %s %s: %w", status.Sport, status.Kind, status.Path, err)
+	} else {
+		status.LastError = ""
+		status.LastErrorAt = nil
+	}
+
+	w.mu.Lock()
+	f.status = status
+	w.mu.Unlock()
+	return changed, err
+}